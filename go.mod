@@ -4,14 +4,15 @@ go 1.22.3
 
 require (
 	github.com/fatih/color v1.17.0
+	github.com/google/uuid v1.6.0
+	github.com/gorilla/websocket v1.5.3
+	github.com/grupawp/termloop v0.0.0-20230531144437-277a1cbf4c14
 	github.com/grupawp/warships-gui/v2 v2.1.8
 	github.com/manifoldco/promptui v0.9.0
 )
 
 require (
 	github.com/chzyer/readline v1.5.1 // indirect
-	github.com/google/uuid v1.6.0 // indirect
-	github.com/grupawp/termloop v0.0.0-20230531144437-277a1cbf4c14 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-runewidth v0.0.15 // indirect
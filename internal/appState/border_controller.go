@@ -1,5 +1,9 @@
 package appState
 
+import (
+	"fmt"
+)
+
 // Directions represents possible directions to check for a ship
 var Directions = [][]int{
 	{1, 0},
@@ -12,6 +16,25 @@ var Directions = [][]int{
 	{1, -1},
 }
 
+// ShipDirections represents the directions in which a ship can extend, ships are never diagonal
+var ShipDirections = [][]int{
+	{1, 0},
+	{0, 1},
+	{-1, 0},
+	{0, -1},
+}
+
+// InconsistentResultError represents a sunk result that does not fit the opponent's board
+type InconsistentResultError struct {
+	X, Y   int    // Coordinates of the shot
+	Reason string // Description of the inconsistency
+}
+
+// Error returns the formatted inconsistency error
+func (e InconsistentResultError) Error() string {
+	return fmt.Sprintf("inconsistent sunk result at %c%d: %s", rune('A'+e.X), e.Y+1, e.Reason)
+}
+
 // DrawBorder draws a border around the ship on the board
 func (b *Board) DrawBorder(x, y int) ([][]int, int) {
 	// Finds the ship on the board
@@ -20,9 +43,9 @@ func (b *Board) DrawBorder(x, y int) ([][]int, int) {
 	for _, v := range Directions {
 		for _, s := range shipFound {
 			xA, yA := s[0]+v[0], s[1]+v[1]
-			// If the coordinates are within range and there is no ship there, mark it as a miss
-			if IsWithinBoardLimits(xA, yA) && !IsShipAtCoordinates(xA, yA, b) {
-				b.Mark(xA, yA, Miss)
+			// If the coordinates are within range and nothing is known about the cell, mark it as a border
+			if IsWithinBoardLimits(xA, yA) && b.PlayerState[xA][yA] == Empty {
+				b.Mark(xA, yA, Border)
 			}
 		}
 	}
@@ -34,8 +57,8 @@ func (b *Board) DrawBorder(x, y int) ([][]int, int) {
 func (b *Board) LocateShipOnBoard(x, y int) ([][]int, int) {
 	// Starts from the given coordinates
 	shipPlacement := [][]int{{x, y}}
-	// For each direction, searches for the ship recursively
-	for _, v := range Directions {
+	// For each direction a ship can extend in, searches for the ship recursively
+	for _, v := range ShipDirections {
 		shipPlacement = append(shipPlacement, findShipRecursive(x, y, v, b)...)
 	}
 	// Returns the ship coordinates and its length
@@ -63,3 +86,40 @@ func IsWithinBoardLimits(x, y int) bool {
 func IsShipAtCoordinates(x, y int, b *Board) bool {
 	return b.PlayerState[x][y] == Hit || b.PlayerState[x][y] == Sunk
 }
+
+// CheckSunkShip checks if the located ship can be a sunk ship of the remaining fleet
+func (b *Board) CheckSunkShip(ship [][]int, remaining map[int]int) error {
+	x, y := ship[0][0], ship[0][1]
+	horizontal, vertical := false, false
+	for _, s := range ship[1:] {
+		horizontal = horizontal || s[1] == y
+		vertical = vertical || s[0] == x
+	}
+	if horizontal && vertical {
+		return InconsistentResultError{X: x, Y: y, Reason: "hits form a bent shape"}
+	}
+	if remaining[len(ship)] <= 0 {
+		return InconsistentResultError{X: x, Y: y, Reason: fmt.Sprintf("no ship of length %d left", len(ship))}
+	}
+
+	// Ships never touch, so no other hit may be next to the sunk ship
+	for _, s := range ship {
+		for _, v := range Directions {
+			xA, yA := s[0]+v[0], s[1]+v[1]
+			if IsWithinBoardLimits(xA, yA) && IsShipAtCoordinates(xA, yA, b) && !containsCell(ship, xA, yA) {
+				return InconsistentResultError{X: x, Y: y, Reason: "another hit touches the sunk ship"}
+			}
+		}
+	}
+	return nil
+}
+
+// containsCell checks if the cell is a part of the ship
+func containsCell(ship [][]int, x, y int) bool {
+	for _, s := range ship {
+		if s[0] == x && s[1] == y {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package appState
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FleetLengths lists the lengths of all ships of the fleet, longest first
+var FleetLengths = []int{4, 3, 3, 2, 2, 2, 1, 1, 1, 1}
+
+// Results of a shot, the same as the ones sent by the server
+const (
+	ResultMiss = "miss"
+	ResultHit  = "hit"
+	ResultSunk = "sunk"
+)
+
+// ParseCoord converts a coordinate like E7 to board coordinates
+func ParseCoord(coord string) (int, int, error) {
+	if len(coord) < 2 || len(coord) > 3 || coord[0] < 'A' || coord[0] > 'J' {
+		return 0, 0, fmt.Errorf("invalid coordinate %q", coord)
+	}
+	row, err := strconv.Atoi(coord[1:])
+	if err != nil || row < 1 || row > 10 {
+		return 0, 0, fmt.Errorf("invalid coordinate %q", coord)
+	}
+	return int(coord[0] - 'A'), row - 1, nil
+}
+
+// FormatCoord converts board coordinates to a coordinate like E7
+func FormatCoord(x, y int) string {
+	return string(rune('A'+x)) + strconv.Itoa(y+1)
+}
@@ -0,0 +1,85 @@
+package appState
+
+import (
+	"sort"
+)
+
+// ShipStatus represents the damage state of one of the player's ships
+type ShipStatus struct {
+	Length int // Number of cells of the ship
+	Hits   int // Number of cells hit by the opponent
+}
+
+// IsSunk checks if all cells of the ship were hit
+func (s ShipStatus) IsSunk() bool {
+	return s.Hits == s.Length
+}
+
+// GetPlayerFleet returns the damage state of every ship on the player's board
+func (g *GameState) GetPlayerFleet() []ShipStatus {
+	g.m.Lock()
+	defer g.m.Unlock()
+	return fleetFromBoard(g.playerBoard.PlayerState)
+}
+
+// fleetFromBoard groups ship cells into ships and counts the hits on each of them
+func fleetFromBoard(states [10][10]string) []ShipStatus {
+	var fleet []ShipStatus
+	visited := [10][10]bool{}
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if visited[x][y] || !isPlayerShipCell(states[x][y]) {
+				continue
+			}
+			var ship ShipStatus
+			for _, c := range collectShipCells(x, y, states, &visited) {
+				ship.Length++
+				if states[c[0]][c[1]] != Ship {
+					ship.Hits++
+				}
+			}
+			fleet = append(fleet, ship)
+		}
+	}
+
+	// Longest ships first, the most damaged first among ships of the same length
+	sort.SliceStable(fleet, func(i, j int) bool {
+		if fleet[i].Length != fleet[j].Length {
+			return fleet[i].Length > fleet[j].Length
+		}
+		return fleet[i].Hits > fleet[j].Hits
+	})
+	return fleet
+}
+
+// markSunkShip marks the whole ship as sunk once every cell of it was hit
+func (b *Board) markSunkShip(x, y int) {
+	visited := [10][10]bool{}
+	cells := collectShipCells(x, y, b.PlayerState, &visited)
+	for _, c := range cells {
+		if b.PlayerState[c[0]][c[1]] == Ship {
+			return
+		}
+	}
+	for _, c := range cells {
+		b.Mark(c[0], c[1], Sunk)
+	}
+}
+
+// collectShipCells finds all ship cells connected to the given one horizontally or vertically
+func collectShipCells(x, y int, states [10][10]string, visited *[10][10]bool) [][]int {
+	visited[x][y] = true
+	cells := [][]int{{x, y}}
+	for _, v := range ShipDirections {
+		xA, yA := x+v[0], y+v[1]
+		if IsWithinBoardLimits(xA, yA) && !visited[xA][yA] && isPlayerShipCell(states[xA][yA]) {
+			cells = append(cells, collectShipCells(xA, yA, states, visited)...)
+		}
+	}
+	return cells
+}
+
+// isPlayerShipCell checks if a cell of the player's board belongs to a ship
+func isPlayerShipCell(s string) bool {
+	return s == Ship || s == Hit || s == Sunk
+}
@@ -6,11 +6,12 @@ import (
 
 // Constants representing the state of a cell in the game board
 const (
-	Ship  = "Ship"
-	Empty = ""
-	Hit   = "Hit"
-	Miss  = "Miss"
-	Sunk  = "Sunk"
+	Ship   = "Ship"
+	Empty  = ""
+	Hit    = "Hit"
+	Miss   = "Miss"
+	Sunk   = "Sunk"
+	Border = "Border" // Empty cell next to a sunk ship, a miss known without shooting at it
 )
 
 // InitializeNewGameState initializes a new game state
@@ -21,12 +22,7 @@ func InitializeNewGameState() *GameState {
 		opponent:      &Player{},
 		playerBoard:   NewBoard(),
 		opponentBoard: NewBoard(),
-		oppShipsSun: map[int]int{
-			1: 4,
-			2: 3,
-			3: 2,
-			4: 1,
-		},
+		oppShipsSun:   newShipCounts(),
 	}
 }
 
@@ -77,6 +73,7 @@ func (g *GameState) MarkPlayerBoard(x, y int) {
 	switch g.playerBoard.PlayerState[x][y] {
 	case Ship:
 		g.playerBoard.PlayerState[x][y] = Hit
+		g.playerBoard.markSunkShip(x, y)
 	case Empty:
 		g.playerBoard.PlayerState[x][y] = Miss
 	}
@@ -90,18 +87,25 @@ func (g *GameState) GetOpponentBoard() [10][10]string {
 }
 
 // MarkOpponentBoard marks a cell on the opponent's board
-func (g *GameState) MarkOpponentBoard(x int, y int, result string) int {
+func (g *GameState) MarkOpponentBoard(x int, y int, result string) (int, error) {
 	g.m.Lock()
 	defer g.m.Unlock()
-	if result == Sunk {
-		g.opponentBoard.PlayerState[x][y] = result
-		_, l := g.opponentBoard.DrawBorder(x, y)
-		g.oppShipsSun[l]--
-		return l
-	}
 	g.opponentBoard.PlayerState[x][y] = result
+	if result != Sunk {
+		return 0, nil
+	}
 
-	return 0
+	ship, l := g.opponentBoard.LocateShipOnBoard(x, y)
+	// An impossible result is reported instead of corrupting the sunk ships count and the border
+	if err := g.opponentBoard.CheckSunkShip(ship, g.oppShipsSun); err != nil {
+		return 0, err
+	}
+	g.opponentBoard.DrawBorder(x, y)
+	for _, s := range ship {
+		g.opponentBoard.Mark(s[0], s[1], Sunk)
+	}
+	g.oppShipsSun[l]--
+	return l, nil
 }
 
 // CheckIfAlreadyHit checks if a cell has already been hit
@@ -109,7 +113,7 @@ func (g *GameState) CheckIfAlreadyHit(x, y int) bool {
 	g.m.Lock()
 	defer g.m.Unlock()
 	s := g.opponentBoard.PlayerState[x][y]
-	return s == Hit || s == Miss
+	return s == Hit || s == Miss || s == Sunk || s == Border
 }
 
 // IncrementHitCount increases the hit count
@@ -188,6 +192,17 @@ func (g *GameState) ClearState() {
 	g.opponentBoard = NewBoard()
 	g.totalShots = 0
 	g.hits = 0
+	g.oppShipsSun = newShipCounts()
+}
+
+// newShipCounts returns the numbers of ships of every length in a full fleet
+func newShipCounts() map[int]int {
+	return map[int]int{
+		1: 4,
+		2: 3,
+		3: 2,
+		4: 1,
+	}
 }
 
 // RetrieveOpponentSunkShipsCount returns the number of sunk ships of the opponent
@@ -197,6 +212,17 @@ func (g *GameState) RetrieveOpponentSunkShipsCount() map[int]int {
 	return g.oppShipsSun
 }
 
+// CopyOpponentShipsLeft returns a copy of the numbers of the opponent's ships still afloat
+func (g *GameState) CopyOpponentShipsLeft() map[int]int {
+	g.m.Lock()
+	defer g.m.Unlock()
+	left := make(map[int]int, len(g.oppShipsSun))
+	for l, n := range g.oppShipsSun {
+		left[l] = n
+	}
+	return left
+}
+
 // UpdateLastGameStatus updates the status of the last game
 func (g *GameState) UpdateLastGameStatus(status string) {
 	g.m.Lock()
@@ -0,0 +1,346 @@
+package auditor
+
+import (
+	"battleships/internal/appState"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// searchBudget limits the number of tried ship positions in a single check
+const searchBudget = 200000
+
+// Errors reported by the auditor
+var (
+	// ErrImpossibleResults means that no legal layout of the opponent's fleet fits the shot results
+	ErrImpossibleResults = errors.New("shot results do not fit any legal layout of the opponent's fleet")
+	// ErrSunkCountMismatch means that the results are possible, but the tracked sunk ships are not
+	ErrSunkCountMismatch = errors.New("tracked sunk ships do not match the shot results")
+)
+
+// observation represents the first shot at a cell and its result
+type observation struct {
+	result string // Result of the shot
+	order  int    // Number of the shot, counted from 1
+}
+
+// Auditor checks if the results of the player's shots are consistent with the rules of the game
+type Auditor struct {
+	cells [10][10]observation // First shot at every cell, an empty result for cells not shot at
+	shots int                 // Number of recorded shots
+	mu    sync.Mutex          // Mutex for data access synchronization
+}
+
+// NewAuditor creates a new auditor with no shots recorded
+func NewAuditor() *Auditor {
+	return &Auditor{}
+}
+
+// Reset forgets all recorded shots
+func (a *Auditor) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.cells = [10][10]observation{}
+	a.shots = 0
+}
+
+// Record records the result of a shot, repeated shots at the same cell are ignored
+func (a *Auditor) Record(x, y int, result string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cells[x][y].result != "" {
+		return
+	}
+	a.shots++
+	a.cells[x][y] = observation{result: result, order: a.shots}
+}
+
+// Check checks if at least one legal layout of the opponent's fleet fits all recorded results.
+// trackedLeft holds the numbers of ships of every length the game believes are still afloat,
+// nil skips comparing them. The check gives up without an error when the search takes too long.
+func (a *Auditor) Check(trackedLeft map[int]int) error {
+	a.mu.Lock()
+	cells := a.cells
+	a.mu.Unlock()
+
+	if trackedLeft != nil {
+		s := newSearch(cells, sunkLengthsFrom(trackedLeft))
+		if s.run() || s.budget <= 0 {
+			return nil
+		}
+	}
+	s := newSearch(cells, nil)
+	if s.run() {
+		if trackedLeft != nil {
+			return fmt.Errorf("%w: %s", ErrSunkCountMismatch, describeLeft(trackedLeft))
+		}
+		return nil
+	}
+	if s.budget <= 0 {
+		return nil
+	}
+	return ErrImpossibleResults
+}
+
+// sunkLengthsFrom returns the sorted lengths of sunk ships from the numbers of ships still afloat,
+// it is empty rather than nil when nothing is sunk, so the search still requires no sunk ships
+func sunkLengthsFrom(left map[int]int) []int {
+	total := map[int]int{}
+	for _, l := range appState.FleetLengths {
+		total[l]++
+	}
+	sunk := []int{}
+	for l, n := range total {
+		for i := left[l]; i < n; i++ {
+			sunk = append(sunk, l)
+		}
+	}
+	sort.Ints(sunk)
+	return sunk
+}
+
+// describeLeft formats the numbers of ships still afloat
+func describeLeft(left map[int]int) string {
+	return fmt.Sprintf("ships left by length 4/3/2/1: %d/%d/%d/%d", left[4], left[3], left[2], left[1])
+}
+
+// search looks for a layout of the fleet fitting the observations with backtracking
+type search struct {
+	cells    [10][10]observation
+	occupied [10][10]bool // Cells covered by placed ships
+	left     map[int]int  // Numbers of ships of every length not placed yet
+	sunk     []int        // Lengths of ships placed as sunk
+	wantSunk []int        // Required sorted lengths of sunk ships, nil for any
+	budget   int          // Number of ship positions that can still be tried
+}
+
+// newSearch creates a search over the observations
+func newSearch(cells [10][10]observation, wantSunk []int) *search {
+	s := &search{cells: cells, left: map[int]int{}, wantSunk: wantSunk, budget: searchBudget}
+	for _, l := range appState.FleetLengths {
+		s.left[l]++
+	}
+	return s
+}
+
+// run returns true when a fitting layout exists
+func (s *search) run() bool {
+	if s.budget <= 0 {
+		return false
+	}
+	x, y, ok := s.uncoveredShip()
+	if !ok {
+		if s.wantSunk != nil && !sameLengths(s.sunk, s.wantSunk) {
+			return false
+		}
+		return s.placeRest(0)
+	}
+
+	for length, n := range s.left {
+		if n == 0 {
+			continue
+		}
+		for _, ship := range segmentsThrough(x, y, length) {
+			s.budget--
+			sunk, ok := s.fits(ship)
+			if !ok {
+				continue
+			}
+			s.place(ship, length, sunk)
+			if s.run() {
+				return true
+			}
+			s.remove(ship, length, sunk)
+		}
+	}
+	return false
+}
+
+// uncoveredShip returns the first hit or sunk cell not covered by a placed ship
+func (s *search) uncoveredShip() (int, int, bool) {
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			r := s.cells[x][y].result
+			if (r == appState.ResultHit || r == appState.ResultSunk) && !s.occupied[x][y] {
+				return x, y, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// placeRest places the remaining ships on cells not shot at, longest first
+func (s *search) placeRest(minIndex int) bool {
+	length := 0
+	for _, l := range appState.FleetLengths {
+		if s.left[l] > 0 {
+			length = l
+			break
+		}
+	}
+	if length == 0 {
+		return true
+	}
+	// Ships of the same length are placed in increasing order of position to skip symmetric layouts
+	for i := minIndex; i < 200; i++ {
+		if s.budget <= 0 {
+			return false
+		}
+		x, y, horizontal := i/20, (i/2)%10, i%2 == 0
+		ship, ok := segment(x, y, length, horizontal)
+		if !ok {
+			continue
+		}
+		s.budget--
+		if _, ok := s.fits(ship); !ok || s.isShot(ship) {
+			continue
+		}
+		s.place(ship, length, false)
+		next := 0
+		if s.left[length] > 0 {
+			next = i + 1
+		}
+		if s.placeRest(next) {
+			return true
+		}
+		s.remove(ship, length, false)
+	}
+	return false
+}
+
+// fits checks if the ship can be placed and returns whether it is one of the sunk ships
+func (s *search) fits(ship [][2]int) (bool, bool) {
+	latest, sunkCells, shot := 0, 0, 0
+	latestSunk := false
+	for _, c := range ship {
+		o := s.cells[c[0]][c[1]]
+		if o.result == appState.ResultMiss || s.occupied[c[0]][c[1]] {
+			return false, false
+		}
+		if o.result != "" {
+			shot++
+			if o.order > latest {
+				latest, latestSunk = o.order, o.result == appState.ResultSunk
+			}
+		}
+		if o.result == appState.ResultSunk {
+			sunkCells++
+		}
+	}
+
+	// Ships never touch, so neighbours can not be covered or hit
+	for _, c := range ship {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				xA, yA := c[0]+dx, c[1]+dy
+				if xA < 0 || xA > 9 || yA < 0 || yA > 9 || contains(ship, xA, yA) {
+					continue
+				}
+				r := s.cells[xA][yA].result
+				if s.occupied[xA][yA] || r == appState.ResultHit || r == appState.ResultSunk {
+					return false, false
+				}
+			}
+		}
+	}
+
+	// A ship is reported sunk exactly once, by the shot at its last cell
+	if shot == len(ship) {
+		return true, sunkCells == 1 && latestSunk
+	}
+	return false, sunkCells == 0
+}
+
+// isShot checks if any cell of the ship was shot at
+func (s *search) isShot(ship [][2]int) bool {
+	for _, c := range ship {
+		if s.cells[c[0]][c[1]].result != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// place marks the ship as placed
+func (s *search) place(ship [][2]int, length int, sunk bool) {
+	for _, c := range ship {
+		s.occupied[c[0]][c[1]] = true
+	}
+	s.left[length]--
+	if sunk {
+		s.sunk = append(s.sunk, length)
+	}
+}
+
+// remove takes back a placed ship
+func (s *search) remove(ship [][2]int, length int, sunk bool) {
+	for _, c := range ship {
+		s.occupied[c[0]][c[1]] = false
+	}
+	s.left[length]++
+	if sunk {
+		s.sunk = s.sunk[:len(s.sunk)-1]
+	}
+}
+
+// segmentsThrough returns all straight ships of the given length covering the cell
+func segmentsThrough(x, y, length int) [][][2]int {
+	var ships [][][2]int
+	for offset := 0; offset < length; offset++ {
+		if ship, ok := segment(x-offset, y, length, true); ok {
+			ships = append(ships, ship)
+		}
+		if length == 1 {
+			continue
+		}
+		if ship, ok := segment(x, y-offset, length, false); ok {
+			ships = append(ships, ship)
+		}
+	}
+	return ships
+}
+
+// segment returns the cells of a ship starting at the given cell, false when it leaves the board
+func segment(x, y, length int, horizontal bool) ([][2]int, bool) {
+	if x < 0 || y < 0 {
+		return nil, false
+	}
+	ship := make([][2]int, 0, length)
+	for i := 0; i < length; i++ {
+		c := [2]int{x, y + i}
+		if horizontal {
+			c = [2]int{x + i, y}
+		}
+		if c[0] > 9 || c[1] > 9 {
+			return nil, false
+		}
+		ship = append(ship, c)
+	}
+	return ship, true
+}
+
+// contains checks if the cell is a part of the ship
+func contains(ship [][2]int, x, y int) bool {
+	for _, c := range ship {
+		if c[0] == x && c[1] == y {
+			return true
+		}
+	}
+	return false
+}
+
+// sameLengths compares lengths of sunk ships regardless of their order
+func sameLengths(got, want []int) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	sorted := append([]int{}, got...)
+	sort.Ints(sorted)
+	for i := range sorted {
+		if sorted[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+// Package boardFormat converts fleets and boards to formats that are easy to share and store.
+//
+// A code is a base32 string made of a format byte, the payload and a checksum byte.
+// Fleet codes hold one bit per field, board codes hold three bits per field with its appState mark.
+package boardFormat
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Format bytes of the codes, a new version of a format gets a new byte
+const (
+	fleetFormatV1 = 0x01
+	boardFormatV1 = 0x02
+)
+
+// Sizes of the payloads in bytes
+const (
+	fleetPayloadSize = 13 // 100 bits, one per field
+	boardPayloadSize = 38 // 300 bits, three per field
+)
+
+// ErrInvalidCode means that the code is damaged or is not a code of this application
+var ErrInvalidCode = errors.New("invalid code")
+
+// codeEncoding is the base32 alphabet without padding, codes are case insensitive
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// boardMarks lists the marks of a board, a mark is stored as its index
+var boardMarks = []string{appState.Empty, appState.Ship, appState.Hit, appState.Miss, appState.Sunk, appState.Border}
+
+// EncodeFleet returns the code of a valid fleet
+func EncodeFleet(coords []string) (string, error) {
+	if _, err := localGame.NewFleet(coords); err != nil {
+		return "", err
+	}
+	payload := make([]byte, fleetPayloadSize)
+	for _, c := range coords {
+		x, y, _ := appState.ParseCoord(c)
+		setBits(payload, fieldIndex(x, y), 1, 1)
+	}
+	return encode(fleetFormatV1, payload), nil
+}
+
+// DecodeFleet returns the fields of the fleet in the code, ordered by column and row
+func DecodeFleet(code string) ([]string, error) {
+	format, payload, err := decode(code)
+	if err != nil {
+		return nil, err
+	}
+	if format != fleetFormatV1 || len(payload) != fleetPayloadSize {
+		return nil, fmt.Errorf("%w: not a fleet code", ErrInvalidCode)
+	}
+	var coords []string
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if getBits(payload, fieldIndex(x, y), 1) == 1 {
+				coords = append(coords, appState.FormatCoord(x, y))
+			}
+		}
+	}
+	if _, err := localGame.NewFleet(coords); err != nil {
+		return nil, err
+	}
+	return coords, nil
+}
+
+// EncodeBoard returns the code of the marks of a board
+func EncodeBoard(b *appState.Board) (string, error) {
+	payload := make([]byte, boardPayloadSize)
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			mark := indexOf(boardMarks, b.PlayerState[x][y])
+			if mark < 0 {
+				return "", fmt.Errorf("unknown mark %q at %s", b.PlayerState[x][y], appState.FormatCoord(x, y))
+			}
+			setBits(payload, 3*fieldIndex(x, y), 3, mark)
+		}
+	}
+	return encode(boardFormatV1, payload), nil
+}
+
+// DecodeBoard returns the board in the code, a fleet code gives a board with the ships of the fleet
+func DecodeBoard(code string) (*appState.Board, error) {
+	format, payload, err := decode(code)
+	if err != nil {
+		return nil, err
+	}
+	b := appState.NewBoard()
+	switch {
+	case format == fleetFormatV1:
+		coords, err := DecodeFleet(code)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range coords {
+			x, y, _ := appState.ParseCoord(c)
+			b.Mark(x, y, appState.Ship)
+		}
+	case format == boardFormatV1 && len(payload) == boardPayloadSize:
+		for x := 0; x < 10; x++ {
+			for y := 0; y < 10; y++ {
+				mark := getBits(payload, 3*fieldIndex(x, y), 3)
+				if mark >= len(boardMarks) {
+					return nil, fmt.Errorf("%w: unknown mark at %s", ErrInvalidCode, appState.FormatCoord(x, y))
+				}
+				b.Mark(x, y, boardMarks[mark])
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown format", ErrInvalidCode)
+	}
+	return b, nil
+}
+
+// encode joins the format byte, the payload and the checksum into a code
+func encode(format byte, payload []byte) string {
+	data := append([]byte{format}, payload...)
+	data = append(data, checksum(data))
+	return codeEncoding.EncodeToString(data)
+}
+
+// decode splits a code into the format byte and the payload, spaces and dashes in the code are ignored
+func decode(code string) (byte, []byte, error) {
+	code = strings.ToUpper(strings.NewReplacer(" ", "", "-", "", "\n", "", "\t", "").Replace(code))
+	data, err := codeEncoding.DecodeString(code)
+	if err != nil || len(data) < 2 {
+		return 0, nil, ErrInvalidCode
+	}
+	if checksum(data[:len(data)-1]) != data[len(data)-1] {
+		return 0, nil, fmt.Errorf("%w: wrong checksum, check for typos", ErrInvalidCode)
+	}
+	return data[0], data[1 : len(data)-1], nil
+}
+
+// checksum returns the sum of the bytes modulo 256
+func checksum(data []byte) byte {
+	var sum byte
+	for _, b := range data {
+		sum += b
+	}
+	return sum
+}
+
+// fieldIndex returns the position of the field counted row by row
+func fieldIndex(x, y int) int {
+	return 10*y + x
+}
+
+// setBits writes the lowest bits of the value starting at the given bit of the payload
+func setBits(payload []byte, bit, count, value int) {
+	for i := 0; i < count; i++ {
+		if value&(1<<i) != 0 {
+			payload[(bit+i)/8] |= 1 << ((bit + i) % 8)
+		}
+	}
+}
+
+// getBits reads a value written with setBits
+func getBits(payload []byte, bit, count int) int {
+	value := 0
+	for i := 0; i < count; i++ {
+		if payload[(bit+i)/8]&(1<<((bit+i)%8)) != 0 {
+			value |= 1 << i
+		}
+	}
+	return value
+}
+
+// indexOf returns the index of the mark, -1 when it is missing
+func indexOf(marks []string, mark string) int {
+	for i, m := range marks {
+		if m == mark {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,119 @@
+package boardFormat
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"errors"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// testBoard returns a board with every mark on it
+func testBoard() *appState.Board {
+	b := appState.NewBoard()
+	for i, mark := range boardMarks {
+		b.Mark(i, i, mark)
+		b.Mark(9-i, i, mark)
+	}
+	return b
+}
+
+func TestFleetCodeRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		coords := localGame.RandomLayout(rng)
+		code, err := EncodeFleet(coords)
+		if err != nil {
+			t.Fatalf("EncodeFleet(%v): %v", coords, err)
+		}
+		// Codes are case insensitive and may be split with spaces and dashes
+		decoded, err := DecodeFleet(strings.ToLower(code[:5]) + "-" + code[5:10] + " " + code[10:])
+		if err != nil {
+			t.Fatalf("DecodeFleet(%q): %v", code, err)
+		}
+		sort.Strings(coords)
+		sort.Strings(decoded)
+		if strings.Join(decoded, ",") != strings.Join(coords, ",") {
+			t.Errorf("DecodeFleet(%q) = %v, want %v", code, decoded, coords)
+		}
+	}
+}
+
+func TestEncodeFleetRejectsInvalidFleet(t *testing.T) {
+	if _, err := EncodeFleet([]string{"A1", "A2"}); !errors.Is(err, localGame.ErrInvalidFleet) {
+		t.Errorf("EncodeFleet of a partial fleet returned %v, want %v", err, localGame.ErrInvalidFleet)
+	}
+}
+
+func TestBoardCodeRoundTrip(t *testing.T) {
+	b := testBoard()
+	code, err := EncodeBoard(b)
+	if err != nil {
+		t.Fatalf("EncodeBoard: %v", err)
+	}
+	decoded, err := DecodeBoard(code)
+	if err != nil {
+		t.Fatalf("DecodeBoard(%q): %v", code, err)
+	}
+	if decoded.PlayerState != b.PlayerState {
+		t.Errorf("DecodeBoard(%q) = %v, want %v", code, decoded.PlayerState, b.PlayerState)
+	}
+}
+
+func TestDecodeBoardFromFleetCode(t *testing.T) {
+	coords := localGame.RandomLayout(rand.New(rand.NewSource(2)))
+	code, err := EncodeFleet(coords)
+	if err != nil {
+		t.Fatalf("EncodeFleet: %v", err)
+	}
+	b, err := DecodeBoard(code)
+	if err != nil {
+		t.Fatalf("DecodeBoard(%q): %v", code, err)
+	}
+	for _, c := range coords {
+		x, y, _ := appState.ParseCoord(c)
+		if b.PlayerState[x][y] != appState.Ship {
+			t.Errorf("field %s is %q, want %q", c, b.PlayerState[x][y], appState.Ship)
+		}
+	}
+}
+
+func TestDecodeBadChecksum(t *testing.T) {
+	code, err := EncodeBoard(testBoard())
+	if err != nil {
+		t.Fatalf("EncodeBoard: %v", err)
+	}
+	data, err := codeEncoding.DecodeString(code)
+	if err != nil {
+		t.Fatalf("decoding %q: %v", code, err)
+	}
+	data[len(data)-1]++
+	damaged := codeEncoding.EncodeToString(data)
+
+	if _, err := DecodeBoard(damaged); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("DecodeBoard with a bad checksum returned %v, want %v", err, ErrInvalidCode)
+	}
+	if _, err := DecodeFleet(damaged); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("DecodeFleet with a bad checksum returned %v, want %v", err, ErrInvalidCode)
+	}
+}
+
+func TestDecodeWrongFormat(t *testing.T) {
+	unknown := encode(0x7f, make([]byte, boardPayloadSize))
+	if _, err := DecodeBoard(unknown); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("DecodeBoard with an unknown format returned %v, want %v", err, ErrInvalidCode)
+	}
+	if _, err := DecodeFleet(unknown); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("DecodeFleet with an unknown format returned %v, want %v", err, ErrInvalidCode)
+	}
+
+	board, err := EncodeBoard(testBoard())
+	if err != nil {
+		t.Fatalf("EncodeBoard: %v", err)
+	}
+	if _, err := DecodeFleet(board); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("DecodeFleet of a board code returned %v, want %v", err, ErrInvalidCode)
+	}
+}
@@ -0,0 +1,89 @@
+package boardFormat
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"fmt"
+	"strings"
+)
+
+// GridMarks maps board marks to the characters of text grids
+var GridMarks = map[string]byte{
+	appState.Empty:  '.',
+	appState.Ship:   '#',
+	appState.Hit:    'x',
+	appState.Miss:   'o',
+	appState.Sunk:   '*',
+	appState.Border: '-',
+}
+
+// FormatGrid returns the board as 10 lines of 10 characters, row 1 first and columns A to J in every line
+func FormatGrid(b *appState.Board) string {
+	var sb strings.Builder
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			mark, ok := GridMarks[b.PlayerState[x][y]]
+			if !ok {
+				mark = '?'
+			}
+			sb.WriteByte(mark)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// ParseGrid reads a board written as a text grid.
+// Spaces are ignored, so are empty lines, lines starting with "//" and a leading row number in every line.
+// A line of column letters like "A B C D E F G H I J" is skipped as a header.
+func ParseGrid(text string) (*appState.Board, error) {
+	marks := make(map[byte]string, len(GridMarks))
+	for mark, ch := range GridMarks {
+		marks[ch] = mark
+	}
+
+	b := appState.NewBoard()
+	y := 0
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+		line = strings.TrimLeft(line, "0123456789")
+		if line == "" || strings.HasPrefix(line, "//") || line == "ABCDEFGHIJ" {
+			continue
+		}
+		if y == 10 {
+			return nil, fmt.Errorf("line %d: the grid has more than 10 rows", n+1)
+		}
+		if len(line) != 10 {
+			return nil, fmt.Errorf("line %d: a row needs 10 fields, found %d", n+1, len(line))
+		}
+		for x := 0; x < 10; x++ {
+			mark, ok := marks[line[x]]
+			if !ok {
+				return nil, fmt.Errorf("line %d: unknown mark %q, use . # x o * -", n+1, line[x])
+			}
+			b.Mark(x, y, mark)
+		}
+		y++
+	}
+	if y != 10 {
+		return nil, fmt.Errorf("the grid needs 10 rows, found %d", y)
+	}
+	return b, nil
+}
+
+// FleetFromGrid returns the fleet drawn on the board, hit and sunk fields count as parts of ships
+func FleetFromGrid(b *appState.Board) ([]string, error) {
+	var coords []string
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			switch b.PlayerState[x][y] {
+			case appState.Ship, appState.Hit, appState.Sunk:
+				coords = append(coords, appState.FormatCoord(x, y))
+			}
+		}
+	}
+	if _, err := localGame.NewFleet(coords); err != nil {
+		return nil, err
+	}
+	return coords, nil
+}
@@ -0,0 +1,88 @@
+package boardFormat
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGridRoundTrip(t *testing.T) {
+	b := testBoard()
+	grid := FormatGrid(b)
+	parsed, err := ParseGrid(grid)
+	if err != nil {
+		t.Fatalf("ParseGrid(%q): %v", grid, err)
+	}
+	if parsed.PlayerState != b.PlayerState {
+		t.Errorf("ParseGrid(FormatGrid(b)) = %v, want %v", parsed.PlayerState, b.PlayerState)
+	}
+	if again := FormatGrid(parsed); again != grid {
+		t.Errorf("FormatGrid after a round trip = %q, want %q", again, grid)
+	}
+}
+
+func TestParseGridSkipsHeaderAndRowNumbers(t *testing.T) {
+	b := testBoard()
+	var sb strings.Builder
+	sb.WriteString("// A position worth keeping\n\n   A B C D E F G H I J\n")
+	for y, line := range strings.Fields(FormatGrid(b)) {
+		sb.WriteString(fmt.Sprintf("%2d %s\n", y+1, strings.Join(strings.Split(line, ""), " ")))
+	}
+
+	parsed, err := ParseGrid(sb.String())
+	if err != nil {
+		t.Fatalf("ParseGrid(%q): %v", sb.String(), err)
+	}
+	if parsed.PlayerState != b.PlayerState {
+		t.Errorf("ParseGrid(%q) = %v, want %v", sb.String(), parsed.PlayerState, b.PlayerState)
+	}
+}
+
+func TestParseGridErrors(t *testing.T) {
+	row := strings.Repeat(".", 10) + "\n"
+	tests := []struct {
+		name string
+		grid string
+	}{
+		{"too few rows", strings.Repeat(row, 9)},
+		{"too many rows", strings.Repeat(row, 11)},
+		{"short row", strings.Repeat(row, 9) + ".........\n"},
+		{"unknown mark", strings.Repeat(row, 9) + ".........?\n"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseGrid(tt.grid); err == nil {
+			t.Errorf("ParseGrid with %s returned no error", tt.name)
+		}
+	}
+}
+
+func TestFleetFromGrid(t *testing.T) {
+	coords := localGame.RandomLayout(rand.New(rand.NewSource(3)))
+	b := appState.NewBoard()
+	for i, c := range coords {
+		x, y, _ := appState.ParseCoord(c)
+		// Hit fields still belong to the fleet
+		mark := appState.Ship
+		if i == 0 {
+			mark = appState.Hit
+		}
+		b.Mark(x, y, mark)
+	}
+	parsed, err := ParseGrid(FormatGrid(b))
+	if err != nil {
+		t.Fatalf("ParseGrid: %v", err)
+	}
+	fleet, err := FleetFromGrid(parsed)
+	if err != nil {
+		t.Fatalf("FleetFromGrid: %v", err)
+	}
+	sort.Strings(coords)
+	sort.Strings(fleet)
+	if strings.Join(fleet, ",") != strings.Join(coords, ",") {
+		t.Errorf("FleetFromGrid = %v, want %v", fleet, coords)
+	}
+}
@@ -0,0 +1,252 @@
+// Package botEngine runs an external bot program that chooses shots and ship placements.
+//
+// The client starts the program and talks to it over its standard input and output, one message per line.
+// Lines sent by the client:
+//
+//	battleships 1       handshake with the protocol version, sent once after starting the program
+//	place               asks for the fleet placement at the start of a game
+//	board               followed by 10 rows of the opponent's board from row 1, columns A to J in each row:
+//	                    '.' not shot at, 'o' miss, '-' empty next to a sunk ship, 'x' hit, '*' sunk
+//	fleet 4 3 3 2       lengths of the opponent's ships still afloat
+//	timer 42            seconds left in the current turn
+//	go                  asks for the next shot
+//	end win             the game ended with win or lose
+//	quit                the program should exit
+//
+// Lines sent by the program:
+//
+//	ready               answer to the handshake
+//	place A1 A2 ...     answer to place with the fields of all ships, or "place random" to let the client place them
+//	fire E7             answer to go
+//	info ...            free text, ignored by the client
+package botEngine
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// protocolVersion is the version of the protocol sent in the handshake
+const protocolVersion = 1
+
+// handshakeTimeout limits the time the program has to answer the handshake
+const handshakeTimeout = 5 * time.Second
+
+// ErrTimeout means that the program did not answer in time
+var ErrTimeout = errors.New("bot did not answer in time")
+
+// boardMarks maps board states to the characters sent to the program
+var boardMarks = map[string]byte{
+	appState.Empty:  '.',
+	appState.Miss:   'o',
+	appState.Border: '-',
+	appState.Hit:    'x',
+	appState.Sunk:   '*',
+}
+
+// Position represents everything the program gets before choosing a shot
+type Position struct {
+	OppBoard  [10][10]string // Opponent's board, indexed by column and row
+	ShipsLeft map[int]int    // Numbers of the opponent's ships still afloat by length
+	Timer     int            // Seconds left in the turn
+}
+
+// Engine represents a running bot program
+type Engine struct {
+	cmd   *exec.Cmd      // Running program
+	in    io.WriteCloser // Standard input of the program
+	lines chan string    // Lines printed by the program, closed when it exits
+	mu    sync.Mutex     // Mutex keeping one question at a time
+}
+
+// Start starts the bot program, the command is split on spaces into the program and its arguments
+func Start(command string) (*Engine, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty bot command")
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("error starting the bot: %w", err)
+	}
+
+	e := &Engine{cmd: cmd, in: in, lines: make(chan string)}
+	go e.read(out)
+
+	if err := e.send(fmt.Sprintf("battleships %d", protocolVersion)); err != nil {
+		_ = e.Close()
+		return nil, err
+	}
+	if _, err := e.expect("ready", handshakeTimeout); err != nil {
+		_ = e.Close()
+		return nil, fmt.Errorf("bot handshake failed: %w", err)
+	}
+	return e, nil
+}
+
+// read passes lines printed by the program to the engine
+func (e *Engine) read(out io.Reader) {
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		e.lines <- strings.TrimSpace(scanner.Text())
+	}
+	close(e.lines)
+}
+
+// send sends a message to the program
+func (e *Engine) send(lines ...string) error {
+	_, err := io.WriteString(e.in, strings.Join(lines, "\n")+"\n")
+	if err != nil {
+		return fmt.Errorf("error writing to the bot: %w", err)
+	}
+	return nil
+}
+
+// expect waits for a line starting with the keyword and returns the rest of it, other lines are skipped
+func (e *Engine) expect(keyword string, timeout time.Duration) (string, error) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case <-deadline:
+			return "", ErrTimeout
+		case line, ok := <-e.lines:
+			if !ok {
+				return "", fmt.Errorf("bot exited")
+			}
+			fields := strings.Fields(line)
+			if len(fields) > 0 && fields[0] == keyword {
+				return strings.Join(fields[1:], " "), nil
+			}
+		}
+	}
+}
+
+// drain skips lines left from questions that timed out
+func (e *Engine) drain() {
+	for {
+		select {
+		case <-e.lines:
+		default:
+			return
+		}
+	}
+}
+
+// Placement asks the program for the fleet, it returns nil when the program leaves the placement to the client
+func (e *Engine) Placement(timeout time.Duration) ([]string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.drain()
+	if err := e.send("place"); err != nil {
+		return nil, err
+	}
+	answer, err := e.expect("place", timeout)
+	if err != nil {
+		return nil, err
+	}
+	if answer == "random" {
+		return nil, nil
+	}
+	coords := strings.Fields(strings.ToUpper(answer))
+	if _, err := localGame.NewFleet(coords); err != nil {
+		return nil, fmt.Errorf("bot placed an invalid fleet: %w", err)
+	}
+	return coords, nil
+}
+
+// NextShot sends the position to the program and returns the field it fires at
+func (e *Engine) NextShot(p Position, timeout time.Duration) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.drain()
+	lines := append([]string{"board"}, boardRows(p.OppBoard)...)
+	lines = append(lines, "fleet "+fleetLine(p.ShipsLeft), fmt.Sprintf("timer %d", p.Timer), "go")
+	if err := e.send(lines...); err != nil {
+		return "", err
+	}
+	coord, err := e.expect("fire", timeout)
+	if err != nil {
+		return "", err
+	}
+	coord = strings.ToUpper(coord)
+	if _, _, err := appState.ParseCoord(coord); err != nil {
+		return "", fmt.Errorf("bot fired at %q: %w", coord, err)
+	}
+	return coord, nil
+}
+
+// GameOver tells the program how the game ended
+func (e *Engine) GameOver(result string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_ = e.send("end " + result)
+}
+
+// Close asks the program to exit and kills it when it does not
+func (e *Engine) Close() error {
+	_ = e.send("quit")
+	_ = e.in.Close()
+	done := make(chan error, 1)
+	go func() {
+		done <- e.cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(handshakeTimeout):
+		_ = e.cmd.Process.Kill()
+		return <-done
+	}
+}
+
+// boardRows formats the board as rows of characters
+func boardRows(board [10][10]string) []string {
+	rows := make([]string, 10)
+	for y := 0; y < 10; y++ {
+		row := make([]byte, 10)
+		for x := 0; x < 10; x++ {
+			mark, ok := boardMarks[board[x][y]]
+			if !ok {
+				mark = '.'
+			}
+			row[x] = mark
+		}
+		rows[y] = string(row)
+	}
+	return rows
+}
+
+// fleetLine lists the lengths of ships still afloat, longest first
+func fleetLine(left map[int]int) string {
+	var lengths []int
+	for l, n := range left {
+		for i := 0; i < n; i++ {
+			lengths = append(lengths, l)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(lengths)))
+	parts := make([]string, len(lengths))
+	for i, l := range lengths {
+		parts[i] = fmt.Sprint(l)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/httpClient"
+	"battleships/internal/strategy"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+)
+
+// autoplayPollInterval is the time between game status checks while waiting for the bot
+const autoplayPollInterval = 500 * time.Millisecond
+
+// autoplayRetryDelay is the time to wait before retrying a failed request, it keeps clear of the rate limit
+const autoplayRetryDelay = 2 * time.Second
+
+// autoplayMaxErrors is the number of failed requests in a row after which a game is abandoned
+const autoplayMaxErrors = 10
+
+// AutoplayConfig represents the settings of unattended games against the server bot
+type AutoplayConfig struct {
+	Games    int    // Number of games to play
+	Strategy string // Name of the strategy placing ships and choosing shots
+	Nick     string // Player's nickname, empty for a random one
+	Seed     int64  // Seed of the random choices of the strategy
+}
+
+// autoplayResult represents the outcome of a single unattended game
+type autoplayResult struct {
+	won   bool // Information if the game was won
+	shots int  // Number of shots fired
+}
+
+// Autoplay plays games against the server bot with a strategy and prints a summary of the results
+func Autoplay(ctx context.Context, server httpClient.Server, cfg AutoplayConfig, out io.Writer) error {
+	if !contains(strategy.Names(), cfg.Strategy) {
+		return fmt.Errorf("unknown strategy %q, available strategies: %v", cfg.Strategy, strategy.Names())
+	}
+	rng := rand.New(rand.NewSource(cfg.Seed))
+
+	var results []autoplayResult
+	var err error
+	for i := 0; i < cfg.Games; i++ {
+		var r autoplayResult
+		r, err = playAutoplayGame(ctx, server, cfg, rng, out)
+		if err != nil {
+			break
+		}
+		results = append(results, r)
+		outcome := "lost"
+		if r.won {
+			outcome = "won"
+		}
+		_, _ = fmt.Fprintf(out, "Game %d/%d: %s after %d shots\n", i+1, cfg.Games, outcome, r.shots)
+	}
+	printAutoplaySummary(out, cfg.Strategy, results)
+	return err
+}
+
+// playAutoplayGame plays a single game against the server bot, warnings about the results are written to out
+func playAutoplayGame(ctx context.Context, server httpClient.Server, cfg AutoplayConfig, rng *rand.Rand, out io.Writer) (autoplayResult, error) {
+	s, err := strategy.New(cfg.Strategy, rng)
+	if err != nil {
+		return autoplayResult{}, err
+	}
+	// The game keeps the opponent's board with sunk ships and borders, the strategy reads it
+	g := httpClient.NewLocalGame(server)
+	if _, err := server.StartGame(cfg.Nick, "Autoplay with the "+cfg.Strategy+" strategy", "", s.Place(), true); err != nil {
+		return autoplayResult{}, fmt.Errorf("error starting the game: %w", err)
+	}
+
+	var result autoplayResult
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			_ = server.AbortGame()
+			return result, ctx.Err()
+		default:
+		}
+		if failures >= autoplayMaxErrors {
+			_ = server.AbortGame()
+			return result, fmt.Errorf("game abandoned after %d failed requests: %w", failures, err)
+		}
+
+		var status httpClient.GameStatus
+		status, err = server.GetGameStatus()
+		if err != nil {
+			failures++
+			wait(ctx, autoplayRetryDelay)
+			continue
+		}
+		failures = 0
+
+		if status.GameStatus == "ended" {
+			result.won = status.LastGameStatus == "win"
+			return result, nil
+		}
+		if status.GameStatus != "game_in_progress" || !status.ShouldFire {
+			wait(ctx, autoplayPollInterval)
+			continue
+		}
+
+		x, y, ok := s.NextShot(g.GetOpponentBoard(), g.OpponentShipsLeft())
+		if !ok {
+			_ = server.AbortGame()
+			return result, fmt.Errorf("strategy %s found no field to fire at", cfg.Strategy)
+		}
+		coord := appState.FormatCoord(x, y)
+		var fired httpClient.FireResult
+		fired, err = server.Fire(httpClient.FireData{Coord: coord})
+		if err != nil {
+			failures++
+			wait(ctx, autoplayRetryDelay)
+			continue
+		}
+		result.shots++
+		// The server's result counts even when it does not fit the board tracked here
+		if _, markErr := g.MarkOpponent(coord, fired); markErr != nil {
+			_, _ = fmt.Fprintf(out, "Warning: shot at %s: %v\n", coord, markErr)
+		}
+		s.Observe(x, y, fired.Result)
+	}
+}
+
+// wait waits for the given time or until the context is done
+func wait(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+// printAutoplaySummary prints the win rate and the average number of shots
+func printAutoplaySummary(out io.Writer, name string, results []autoplayResult) {
+	if len(results) == 0 {
+		_, _ = fmt.Fprintf(out, "No games finished with the %s strategy\n", name)
+		return
+	}
+	wins, shots, winShots := 0, 0, 0
+	for _, r := range results {
+		shots += r.shots
+		if r.won {
+			wins++
+			winShots += r.shots
+		}
+	}
+	_, _ = fmt.Fprintf(out, "Strategy %s: %d/%d games won (%.1f %%), %.1f shots per game on average",
+		name, wins, len(results), 100*float64(wins)/float64(len(results)), float64(shots)/float64(len(results)))
+	if wins > 0 {
+		_, _ = fmt.Fprintf(out, ", %.1f shots per won game", float64(winShots)/float64(wins))
+	}
+	_, _ = fmt.Fprintln(out)
+}
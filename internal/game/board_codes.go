@@ -0,0 +1,188 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/boardFormat"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ShareCodes shows and loads fleets and boards as codes and text grid files
+func (a *App) ShareCodes() {
+	answer, err := a.prompts.choose("Fleet and board codes", []string{
+		"Show the code of my fleet",
+		"Load my fleet from a code",
+		"Show a fleet or a board from a code",
+		"Save my fleet to a grid file",
+		"Load my fleet from a grid file",
+		"Save a board of the current game to a grid file",
+	})
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return
+	}
+
+	switch answer {
+	case "Show the code of my fleet":
+		code, err := boardFormat.EncodeFleet(a.game.GetPlayerCoords())
+		if err != nil {
+			fmt.Printf("Place a complete fleet first: %v\n", err)
+			return
+		}
+		fmt.Printf("Code of your fleet: %s\n", code)
+	case "Load my fleet from a code":
+		code, ok := a.promptCode()
+		if !ok {
+			return
+		}
+		coords, err := boardFormat.DecodeFleet(code)
+		if err != nil {
+			fmt.Printf("Error loading the fleet %v\n", err)
+			return
+		}
+		a.loadFleet(coords)
+	case "Show a fleet or a board from a code":
+		code, ok := a.promptCode()
+		if !ok {
+			return
+		}
+		b, err := boardFormat.DecodeBoard(code)
+		if err != nil {
+			fmt.Printf("Error reading the code %v\n", err)
+			return
+		}
+		fmt.Print(formatBoard(b.PlayerState))
+	case "Save my fleet to a grid file":
+		coords := a.game.GetPlayerCoords()
+		if len(coords) == 0 {
+			fmt.Println("Place your ships first")
+			return
+		}
+		path, ok := a.promptPath("Path of the grid file")
+		if !ok {
+			return
+		}
+		grid := boardFormat.FormatGrid(&appState.Board{PlayerState: setStates(coords)})
+		if err := os.WriteFile(path, []byte(grid), 0o644); err != nil {
+			fmt.Printf("Error saving the fleet %v\n", err)
+			return
+		}
+		fmt.Printf("Fleet saved to %s\n", path)
+	case "Load my fleet from a grid file":
+		path, ok := a.promptPath("Path of the grid file")
+		if !ok {
+			return
+		}
+		coords, err := readFleetGrid(path)
+		if err != nil {
+			fmt.Printf("Error loading the fleet %v\n", err)
+			return
+		}
+		a.loadFleet(coords)
+	case "Save a board of the current game to a grid file":
+		a.saveBoardGrid()
+	}
+}
+
+// loadFleet opens the loaded fleet in the placement editor, the board changes only when the player confirms it
+func (a *App) loadFleet(coords []string) {
+	if _, ok := a.ui.(linePlacer); ok {
+		a.game.SetPlayerBoard(coords)
+		fmt.Println("Fleet loaded, it is used in your next game unless you place your ships again.")
+		fmt.Print(formatBoard(setStates(coords)))
+		return
+	}
+
+	editor := newFleetEditor()
+	if err := editor.load(coords); err != nil {
+		fmt.Printf("Error loading the fleet %v\n", err)
+		return
+	}
+	if coords, ok := editFleet(context.Background(), editor); ok {
+		a.game.SetPlayerBoard(coords)
+		fmt.Println("Fleet loaded, it is used in your next game unless you place your ships again.")
+	}
+}
+
+// saveBoardGrid saves the player's or the opponent's board of the current or the last game to a grid file
+func (a *App) saveBoardGrid() {
+	state, err := a.game.GetGameState()
+	if err != nil {
+		fmt.Printf("Error reading the game %v\n", err)
+		return
+	}
+	answer, err := a.prompts.choose("Which board?", []string{"My board", "Opponent's board"})
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return
+	}
+	b := &appState.Board{PlayerState: state.GetPlayerBoard()}
+	if answer == "Opponent's board" {
+		b = &appState.Board{PlayerState: state.GetOpponentBoard()}
+	}
+
+	path, ok := a.promptPath("Path of the grid file")
+	if !ok {
+		return
+	}
+	if err := os.WriteFile(path, []byte(boardFormat.FormatGrid(b)), 0o644); err != nil {
+		fmt.Printf("Error saving the board %v\n", err)
+		return
+	}
+	fmt.Printf("Board saved to %s\n", path)
+}
+
+// readFleetGrid reads a fleet from a text grid file
+func readFleetGrid(path string) ([]string, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	b, err := boardFormat.ParseGrid(string(text))
+	if err != nil {
+		return nil, err
+	}
+	return boardFormat.FleetFromGrid(b)
+}
+
+// promptPath asks for the path of a file
+func (a *App) promptPath(label string) (string, bool) {
+	path, err := a.prompts.ask(label, "", nil)
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return "", false
+	}
+	return path, true
+}
+
+// promptCode asks for a code
+func (a *App) promptCode() (string, bool) {
+	code, err := a.prompts.ask("Enter the code", "", nil)
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return "", false
+	}
+	return code, true
+}
+
+// setStates returns a board with ships on the given fields
+func setStates(coords []string) [10][10]string {
+	var states [10][10]string
+	for _, c := range coords {
+		x, y, _ := appState.ParseCoord(c)
+		states[x][y] = appState.Ship
+	}
+	return states
+}
+
+// formatBoard formats a board with the marks of the plain-text mode
+func formatBoard(states [10][10]string) string {
+	var b strings.Builder
+	b.WriteString("    A B C D E F G H I J\n")
+	for y := 0; y < 10; y++ {
+		b.WriteString(fmt.Sprintf("%3d %s\n", y+1, boardRow(states, y)))
+	}
+	return b.String()
+}
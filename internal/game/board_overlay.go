@@ -0,0 +1,59 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	gui "github.com/grupawp/warships-gui/v2"
+)
+
+// Colors of the marks that the board from warships-gui cannot show by itself
+var (
+	sunkColor   = gui.NewColor(90, 20, 20)
+	borderColor = gui.NewColor(150, 150, 150)
+)
+
+// boardOverlay draws additional marks on top of the fields of a board
+type boardOverlay struct {
+	marks [10][10]*gui.Text // One mark for each field, empty when the board's own mark is enough
+}
+
+// newBoardOverlay creates an overlay for a board with its top left corner at x and y
+func newBoardOverlay(x, y int) *boardOverlay {
+	o := &boardOverlay{}
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			// Fields of the board are 3 characters wide and separated by one column and one row
+			o.marks[i][j] = gui.NewText(x+(i+1)*4, y+(j+1)*2, "", nil)
+		}
+	}
+	return o
+}
+
+// draw adds the overlay to the screen, it has to be drawn after its board
+func (o *boardOverlay) draw(g *gui.GUI) {
+	for _, row := range o.marks {
+		for _, mark := range row {
+			g.Draw(mark)
+		}
+	}
+}
+
+// update sets the marks according to the board state
+func (o *boardOverlay) update(states [10][10]string) {
+	for i, row := range states {
+		for j, s := range row {
+			mark := o.marks[i][j]
+			switch s {
+			case appState.Sunk:
+				mark.SetText(" X ")
+				mark.SetFgColor(gui.White)
+				mark.SetBgColor(sunkColor)
+			case appState.Border:
+				mark.SetText(" . ")
+				mark.SetFgColor(gui.Black)
+				mark.SetBgColor(borderColor)
+			default:
+				mark.SetText("")
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"battleships/internal/botEngine"
+	"battleships/internal/httpClient"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// botPlacementTimeout limits the time the bot program has to place its fleet
+const botPlacementTimeout = 10 * time.Second
+
+// botPlayer represents the state of an external bot program playing instead of the player
+type botPlayer struct {
+	engine    *botEngine.Engine // Running bot program, nil when the player plays
+	pending   atomic.Bool       // Information if the bot is choosing a shot
+	asked     atomic.Bool       // Information if the bot was asked in the current turn
+	lastBoard [10][10]string    // Opponent's board sent with the last question
+}
+
+// UseBot starts the bot program, it chooses shots and placements instead of the player
+func (a *App) UseBot(command string) error {
+	engine, err := botEngine.Start(command)
+	if err != nil {
+		return err
+	}
+	a.bot.engine = engine
+	return nil
+}
+
+// CloseBot stops the bot program
+func (a *App) CloseBot() {
+	if a.bot.engine != nil {
+		_ = a.bot.engine.Close()
+		a.bot.engine = nil
+	}
+}
+
+// botActive checks if the bot plays in the current game, hotseat games are left to the players
+func (a *App) botActive() bool {
+	return a.bot.engine != nil && !a.hotseat
+}
+
+// placeBotShips asks the bot for the fleet, it returns false when the player has to place it
+func (a *App) placeBotShips() bool {
+	coords, err := a.bot.engine.Placement(botPlacementTimeout)
+	if err != nil {
+		fmt.Printf("Error placing ships with the bot %v\n", err)
+		return false
+	}
+	if coords == nil {
+		return false
+	}
+	a.game.SetPlayerBoard(coords)
+	return true
+}
+
+// askBot asks the bot for a shot once per position when it is the player's turn
+func (a *App) askBot(ctx context.Context, status httpClient.GameStatus) {
+	if !a.botActive() || status.GameStatus != "game_in_progress" {
+		return
+	}
+	if !status.ShouldFire {
+		a.bot.asked.Store(false)
+		return
+	}
+
+	// A hit lets the player fire again, the bot is asked again once the board shows the result
+	board := a.game.GetOpponentBoard()
+	if a.bot.pending.Load() || (a.bot.asked.Load() && board == a.bot.lastBoard) {
+		return
+	}
+	a.bot.asked.Store(true)
+	a.bot.lastBoard = board
+	a.bot.pending.Store(true)
+
+	position := botEngine.Position{
+		OppBoard:  board,
+		ShipsLeft: a.game.OpponentShipsLeft(),
+		Timer:     status.Timer,
+	}
+	timeout := time.Duration(max(status.Timer-1, 1)) * time.Second
+	go func() {
+		defer a.bot.pending.Store(false)
+		shot, err := a.bot.engine.NextShot(position, timeout)
+		if err != nil {
+			a.ui.showWarning(fmt.Sprintf("Bot error: %v", err))
+			// The bot is asked again in this turn instead of leaving the turn to the timer
+			a.bot.asked.Store(false)
+			return
+		}
+		// The shot goes through the same path as a click on the opponent's board
+		select {
+		case <-ctx.Done():
+		case a.playerShotsChannel <- shot:
+		}
+	}()
+}
+
+// endBotGame tells the bot how the game ended
+func (a *App) endBotGame(result string) {
+	if a.botActive() {
+		a.bot.engine.GameOver(result)
+	}
+}
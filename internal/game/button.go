@@ -0,0 +1,84 @@
+package game
+
+import (
+	"github.com/google/uuid"
+	tl "github.com/grupawp/termloop"
+	"sync"
+)
+
+// Colors of buttons
+var (
+	buttonColor         = tl.RgbTo256Color(60, 110, 170)
+	disabledButtonColor = tl.RgbTo256Color(120, 120, 120)
+	buttonTextColor     = tl.RgbTo256Color(255, 255, 255)
+)
+
+// button represents a clickable label that sends its action when clicked
+type button struct {
+	id       uuid.UUID
+	x, y     int         // Position of the label
+	label    string      // Text of the button
+	action   string      // Action sent when the button is clicked
+	disabled bool        // Information if clicks are ignored
+	ch       chan string // Channel receiving the action
+	mu       sync.Mutex
+}
+
+// newButton creates a button at x and y sending the action to the channel
+func newButton(x, y int, label, action string, ch chan string) *button {
+	return &button{id: uuid.New(), x: x, y: y, label: " " + label + " ", action: action, ch: ch}
+}
+
+// ID returns the identifier used by the GUI
+func (b *button) ID() uuid.UUID {
+	return b.id
+}
+
+// Drawables returns the objects drawn by the GUI
+func (b *button) Drawables() []tl.Drawable {
+	return []tl.Drawable{b}
+}
+
+// setLabel changes the text of the button
+func (b *button) setLabel(label string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.label = " " + label + " "
+}
+
+// setDisabled turns clicks on or off
+func (b *button) setDisabled(disabled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.disabled = disabled
+}
+
+// Tick handles mouse clicks
+func (b *button) Tick(ev tl.Event) {
+	if ev.Type != tl.EventMouse || ev.Key != tl.MouseLeft {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.disabled || ev.MouseY != b.y || ev.MouseX < b.x || ev.MouseX >= b.x+len(b.label) {
+		return
+	}
+	select {
+	case b.ch <- b.action:
+	default:
+		// The previous action was not handled yet, drop this one
+	}
+}
+
+// Draw draws the label
+func (b *button) Draw(s *tl.Screen) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	bg := buttonColor
+	if b.disabled {
+		bg = disabledButtonColor
+	}
+	for i, ch := range b.label {
+		s.RenderCell(b.x+i, b.y, &tl.Cell{Fg: buttonTextColor, Bg: bg, Ch: ch})
+	}
+}
@@ -2,10 +2,10 @@ package game
 
 import (
 	"battleships/internal/httpClient"
+	"battleships/internal/strategy"
 	"context"
 	"fmt"
-	gui "github.com/grupawp/warships-gui/v2"
-	"github.com/manifoldco/promptui"
+	"io"
 	"sync"
 	"time"
 )
@@ -13,48 +13,57 @@ import (
 // NewApp creates a new instance of the application
 func NewApp(gameStatusChannel chan httpClient.GameStatus, playerShotsChannel chan string, gameStateChannel chan httpClient.GameState) *App {
 	return &App{
-		gui:                NewGui(),
+		ui:                 NewGui(),
+		prompts:            terminalPrompts{},
 		game:               httpClient.NewGame(),
 		playerShotsChannel: playerShotsChannel,
 		gameStatusChannel:  gameStatusChannel,
 		gameStateChannel:   gameStateChannel,
 		errChan:            make(chan error),
 		wg:                 &sync.WaitGroup{},
+		safeguard:          turnSafeguard{threshold: defaultSafeguardThreshold, strategy: strategy.Parity},
+		history:            newGameRecorder(),
 	}
 }
 
+// UsePlainText switches the in-game user interface to the plain-text mode
+func (a *App) UsePlainText(in io.Reader, out io.Writer) {
+	t := NewTextUI(in, out)
+	a.ui = t
+	a.prompts = t
+}
+
+// UseWebUI switches the in-game user interface to the browser
+func (a *App) UseWebUI(w *WebUI) {
+	a.ui = w
+}
+
 // InitGameVersusPlayer starts the game for the player
-func (a *App) InitGameVersusPlayer(ctx context.Context) {
+func (a *App) InitGameVersusPlayer(parent context.Context) {
+	var rematch *rematchPlan
 	for {
-		ctx, cancel := context.WithCancel(ctx)
+		ctx, cancel := context.WithCancel(parent)
 		var wg sync.WaitGroup
 
 		nick, desc := a.game.GetPlayerInfo()
 
-		prompt := promptui.Select{
-			Label: "Do you want to place your ships?",
-			Items: []string{"Yes", "No"},
-		}
-		_, answer, err := prompt.Run()
-		if err != nil {
-			fmt.Printf("Error executing command %v\n", err)
+		if !a.choosePlacement(ctx, rematch) {
+			cancel()
 			return
 		}
-		if answer == "Yes" {
-			a.PlaceShips(ctx)
-		}
 		coords := a.game.GetPlayerCoords()
 
-		promptNick := promptui.Prompt{
-			Label: "Enter opponent's nickname (or leave empty to stay in lobby and wait for a challange) ",
-		}
-		targetNick, err := promptNick.Run()
-		if err != nil {
-			fmt.Printf("Error executing command %v\n", err)
-			return
+		if rematch != nil {
+			a.challenge(ctx, rematch.opponent, nick, desc, coords)
+		} else {
+			targetNick, err := a.prompts.ask("Enter opponent's nickname (or leave empty to stay in lobby and wait for a challange) ", "", nil)
+			if err != nil {
+				fmt.Printf("Error executing command %v\n", err)
+				cancel()
+				return
+			}
+			a.game.StartGame(nick, desc, targetNick, coords, false)
 		}
-
-		a.game.StartGame(nick, desc, targetNick, coords, false)
 		board, err := a.game.LoadPlayerBoard()
 		if err != nil {
 			a.errChan <- err
@@ -66,49 +75,59 @@ func (a *App) InitGameVersusPlayer(ctx context.Context) {
 		wg.Add(8)
 		a.runGameRoutines(ctx, cancel, &wg)
 
-		a.gui.gui.Start(ctx, nil)
-		a.gui.gui.Draw(gui.NewText(1, 0, "Press ctrl+c to leave the game", nil)) // Add this line
+		a.ui.start(ctx)
 
-		promptAbort := promptui.Select{
-			Label: "Abort?",
-			Items: []string{"Yes", "No"},
-		}
-		_, abort, err := promptAbort.Run()
-		if err != nil {
-			fmt.Printf("Error executing command %v\n", err)
-			return
-		}
-		if abort == "Yes" {
-			a.game.AbortGame()
+		// A game that ended has nothing to abort
+		if !a.gameEnded() {
+			abort, err := a.prompts.choose("Abort?", []string{"Yes", "No"})
+			if err != nil {
+				fmt.Printf("Error executing command %v\n", err)
+				return
+			}
+			if abort == "Yes" {
+				a.game.AbortGame()
+			}
 		}
 
 		wg.Wait()
 
-		promptReplay := promptui.Select{
-			Label: "Do you want to play again?",
-			Items: []string{"Yes", "No"},
-		}
-		_, choice, err := promptReplay.Run()
-		if err != nil {
-			fmt.Printf("Error executing command %v\n", err)
-			return
-		}
-		if choice == "No" {
+		var again bool
+		if rematch, again = a.playAgain(parent); !again {
 			break
 		}
 	}
 }
 
+// choosePlacement places the ships for the next game, a rematch with the same layout places them without asking
+func (a *App) choosePlacement(ctx context.Context, rematch *rematchPlan) bool {
+	if rematch != nil && rematch.layout != nil {
+		_, err := a.game.SetPlayerBoard(rematch.layout)
+		if err == nil {
+			return true
+		}
+		fmt.Printf("Error reusing the layout %v\n", err)
+	}
+
+	answer, err := a.prompts.choose("Do you want to place your ships?", placementChoices)
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return false
+	}
+	a.placeShipsByChoice(ctx, answer)
+	return true
+}
+
 // runGameRoutines runs parallel game threads
 func (a *App) runGameRoutines(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitGroup) {
+	a.startRecording()
 	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.updateGameStatus(ctx) })
-	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.gui.handleGameState(ctx, a.gameStateChannel) })
+	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.ui.handleGameState(ctx, a.gameStateChannel) })
 	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.updateGameState(ctx, cancel) })
-	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.gui.displayBoard() })
-	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.gui.handleGameStatus(ctx, a.gameStatusChannel) })
+	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.ui.displayBoard() })
+	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.ui.handleGameStatus(ctx, a.gameStatusChannel) })
 	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.handleError(ctx) })
 	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.readPlayerShots(ctx) })
-	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.gui.listenPlayerShots(ctx, a.playerShotsChannel) })
+	go a.runRoutine(ctx, wg, func(ctx context.Context) { a.ui.listenPlayerShots(ctx, a.playerShotsChannel) })
 }
 
 // runRoutine runs a single thread
@@ -118,25 +137,18 @@ func (a *App) runRoutine(ctx context.Context, wg *sync.WaitGroup, f func(context
 }
 
 // InitGameVersusBot starts the game with a bot
-func (a *App) InitGameVersusBot(ctx context.Context) {
+func (a *App) InitGameVersusBot(parent context.Context) {
+	var rematch *rematchPlan
 	for {
-		ctx, cancel := context.WithCancel(ctx)
+		ctx, cancel := context.WithCancel(parent)
 		var wg sync.WaitGroup
 		wg.Add(8)
 		nick, desc := a.game.GetPlayerInfo()
 
-		prompt := promptui.Select{
-			Label: "Do you want to place your ships?",
-			Items: []string{"Yes", "No"},
-		}
-		_, answer, err := prompt.Run()
-		if err != nil {
-			fmt.Printf("Error executing command %v\n", err)
+		if !a.choosePlacement(ctx, rematch) {
+			cancel()
 			return
 		}
-		if answer == "Yes" {
-			a.PlaceShips(ctx)
-		}
 		coords := a.game.GetPlayerCoords()
 
 		a.game.StartGame(nick, desc, "", coords, true)
@@ -148,30 +160,19 @@ func (a *App) InitGameVersusBot(ctx context.Context) {
 
 		a.runGameRoutines(ctx, cancel, &wg)
 
-		a.gui.gui.Start(ctx, nil)
+		a.ui.start(ctx)
 
-		promptAbort := promptui.Select{
-			Label: "You left the game.",
-		}
-		_, _, err = promptAbort.Run()
-		if err != nil {
-			a.game.AbortGame()
+		if !a.gameEnded() {
+			_, err = a.prompts.choose("You left the game.", nil)
+			if err != nil {
+				a.game.AbortGame()
+			}
 		}
 
 		wg.Wait()
 
-		a.game.LastGameStatus()
-
-		promptReplay := promptui.Select{
-			Label: "Do you want to play again?",
-			Items: []string{"Yes", "No"},
-		}
-		_, choice, err := promptReplay.Run()
-		if err != nil {
-			fmt.Printf("Error executing command %v\n", err)
-			return
-		}
-		if choice == "No" {
+		var again bool
+		if rematch, again = a.playAgain(parent); !again {
 			break
 		}
 	}
@@ -181,6 +182,7 @@ func (a *App) InitGameVersusBot(ctx context.Context) {
 func (a *App) updateGameState(ctx context.Context, cancel context.CancelFunc) {
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
+	var turnPassedAt time.Time
 loop:
 	for {
 		select {
@@ -194,7 +196,12 @@ loop:
 				a.errChan <- err
 				continue
 			}
+			a.recordStatus(state)
 			if state.GameStatus == "ended" {
+				a.game.MarkOpponentShots(state.OppShots)
+				a.saveGame(state)
+				a.endBotGame(state.LastGameStatus)
+				a.resetSafeguard()
 				a.game.ClearState()
 				cancel()
 				return
@@ -205,7 +212,21 @@ loop:
 			}
 			oppShots := state.OppShots
 			a.game.MarkOpponentShots(oppShots)
-			a.gameStatusChannel <- state
+			a.checkTurnTimer(ctx, state)
+			a.askBot(ctx, state)
+			// In hotseat mode the screen closes shortly after the turn passes, so the next player does not see it
+			if a.hotseat && state.GameStatus == "game_in_progress" && !state.ShouldFire {
+				if turnPassedAt.IsZero() {
+					turnPassedAt = time.Now()
+				} else if time.Since(turnPassedAt) > hotseatTurnDelay {
+					cancel()
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+			case a.gameStatusChannel <- state:
+			}
 		}
 	}
 }
@@ -225,14 +246,19 @@ func (a *App) updateGameStatus(ctx context.Context) {
 				a.errChan <- err
 				continue
 			}
-			a.gameStateChannel <- httpClient.GameState{
+			gameState := httpClient.GameState{
 				PlayerBoard:  state.GetPlayerBoard(),
 				OppBoard:     state.GetOpponentBoard(),
 				TotalHits:    state.GetTotalHits(),
 				TotalShots:   state.GetTotalShots(),
 				PlayerDesc:   state.GetPlayerDesc(),
 				OppDesc:      state.GetOppDesc(),
-				OppShipsSunk: state.RetrieveOpponentSunkShipsCount(),
+				OppShipsSunk: state.CopyOpponentShipsLeft(),
+				PlayerFleet:  state.GetPlayerFleet(),
+			}
+			select {
+			case <-ctx.Done():
+			case a.gameStateChannel <- gameState:
 			}
 		}
 	}
@@ -246,7 +272,7 @@ loop:
 		case <-ctx.Done():
 			break loop
 		case err := <-a.errChan:
-			a.gui.gui.Log("Error: %v", err)
+			a.ui.showWarning(fmt.Sprintf("Error: %v", err))
 		}
 	}
 }
@@ -259,31 +285,29 @@ loop:
 		case <-ctx.Done():
 			break loop
 		case shot := <-a.playerShotsChannel:
-			_, _, err := a.game.FireShot(shot)
+			result, _, err := a.game.FireShot(shot)
 			if err != nil {
+				// A shot that did not go through does not use up the bot's turn
+				a.bot.asked.Store(false)
 				a.errChan <- err
+				continue
 			}
+			a.observeShot(shot, result.Result)
+			a.recordShot(shot, result.Result)
+			a.auditShots()
 		}
 	}
 }
 
 // EnterPlayerInfo enters player information
 func (a *App) EnterPlayerInfo() {
-	promptName := promptui.Prompt{
-		Label: "Enter your nickname",
-	}
-
-	name, err := promptName.Run()
+	name, err := a.prompts.ask("Enter your nickname", "", nil)
 	if err != nil {
 		fmt.Printf("Error executing command %v\n", err)
 		return
 	}
 
-	promptDescription := promptui.Prompt{
-		Label: "Enter your description",
-	}
-
-	description, err := promptDescription.Run()
+	description, err := a.prompts.ask("Enter your description", "", nil)
 	if err != nil {
 		fmt.Printf("Error executing command %v\n", err)
 		return
@@ -294,11 +318,7 @@ func (a *App) EnterPlayerInfo() {
 
 // GetPlayerStats gets player statistics
 func (a *App) GetPlayerStats() {
-	promptName := promptui.Prompt{
-		Label: "Enter player's nickname",
-	}
-
-	name, err := promptName.Run()
+	name, err := a.prompts.ask("Enter player's nickname", "", nil)
 	if err != nil {
 		fmt.Printf("Error executing command %v\n", err)
 		return
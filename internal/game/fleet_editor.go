@@ -0,0 +1,298 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"fmt"
+	gui "github.com/grupawp/warships-gui/v2"
+	"math/rand"
+	"sort"
+)
+
+// autoFillAttempts is the number of random tries to fit the remaining ships before giving up
+const autoFillAttempts = 200
+
+// editorShip represents a ship of the fleet in the placement editor
+type editorShip struct {
+	placed     bool // Information if the ship is on the board
+	x, y       int  // Field of the top left end of the ship
+	horizontal bool // Orientation of the ship
+}
+
+// fleetEditor keeps the fleet being placed, every ship has the length from appState.FleetLengths at the same index
+type fleetEditor struct {
+	ships    [10]editorShip   // Ships of the fleet
+	selected int              // Index of the ship being placed or moved
+	undo     [][10]editorShip // Fleets before the last changes
+	redo     [][10]editorShip // Fleets taken back with undo
+}
+
+// newFleetEditor creates an editor with no ships on the board
+func newFleetEditor() *fleetEditor {
+	e := &fleetEditor{}
+	for i := range e.ships {
+		e.ships[i].horizontal = true
+	}
+	return e
+}
+
+// load puts a complete fleet given as fields on the board, the change can be undone
+func (e *fleetEditor) load(coords []string) error {
+	fleet, err := localGame.NewFleet(coords)
+	if err != nil {
+		return err
+	}
+	var ships [][][2]int
+	seen := make(map[[2]int]bool)
+	for _, c := range coords {
+		x, y, _ := appState.ParseCoord(c)
+		if ship := fleet.ShipAt(x, y); !seen[ship[0]] {
+			seen[ship[0]] = true
+			ships = append(ships, ship)
+		}
+	}
+	// The fleet is valid, so sorting by length matches the ships with the lengths in appState.FleetLengths
+	sort.SliceStable(ships, func(i, j int) bool { return len(ships[i]) > len(ships[j]) })
+
+	var loaded [10]editorShip
+	for i, ship := range ships {
+		s := editorShip{placed: true, x: ship[0][0], y: ship[0][1], horizontal: true}
+		for _, c := range ship {
+			s.x, s.y = min(s.x, c[0]), min(s.y, c[1])
+			if c[1] != ship[0][1] {
+				s.horizontal = false
+			}
+		}
+		loaded[i] = s
+	}
+	e.change(func() {
+		e.ships = loaded
+		e.selected = 0
+	})
+	return nil
+}
+
+// shipCells returns the fields covered by a ship of the given length, false when it leaves the board
+func shipCells(s editorShip, length int) ([][2]int, bool) {
+	cells := make([][2]int, 0, length)
+	for i := 0; i < length; i++ {
+		x, y := s.x, s.y+i
+		if s.horizontal {
+			x, y = s.x+i, s.y
+		}
+		if x < 0 || y < 0 || x > 9 || y > 9 {
+			return cells, false
+		}
+		cells = append(cells, [2]int{x, y})
+	}
+	return cells, true
+}
+
+// shipAt returns the index of the placed ship covering the field, -1 for water
+func (e *fleetEditor) shipAt(x, y int) int {
+	for i, s := range e.ships {
+		if !s.placed {
+			continue
+		}
+		cells, _ := shipCells(s, appState.FleetLengths[i])
+		for _, c := range cells {
+			if c[0] == x && c[1] == y {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// fits checks if the ship with the given index can stand in the given position without touching other ships
+func (e *fleetEditor) fits(i int, s editorShip) bool {
+	cells, ok := shipCells(s, appState.FleetLengths[i])
+	if !ok {
+		return false
+	}
+	for _, c := range cells {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				if j := e.shipAt(c[0]+dx, c[1]+dy); j >= 0 && j != i {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// change saves the fleet for undo and applies the change
+func (e *fleetEditor) change(apply func()) {
+	e.undo = append(e.undo, e.ships)
+	e.redo = nil
+	apply()
+}
+
+// selectShip selects the ship with the given index
+func (e *fleetEditor) selectShip(i int) {
+	if i >= 0 && i < len(e.ships) {
+		e.selected = i
+	}
+}
+
+// selectNext selects the next ship, unplaced ships first
+func (e *fleetEditor) selectNext() {
+	for step := 1; step <= len(e.ships); step++ {
+		if i := (e.selected + step) % len(e.ships); !e.ships[i].placed {
+			e.selected = i
+			return
+		}
+	}
+	e.selected = (e.selected + 1) % len(e.ships)
+}
+
+// place puts the selected ship with its top left end at the field, a placed ship moves there
+func (e *fleetEditor) place(x, y int) error {
+	s := e.ships[e.selected]
+	s.placed, s.x, s.y = true, x, y
+	if !e.fits(e.selected, s) {
+		return fmt.Errorf("the ship of length %d does not fit at %s", appState.FleetLengths[e.selected], appState.FormatCoord(x, y))
+	}
+	wasPlaced := e.ships[e.selected].placed
+	e.change(func() { e.ships[e.selected] = s })
+	if !wasPlaced {
+		e.selectNext()
+	}
+	return nil
+}
+
+// rotate turns the selected ship around its top left end
+func (e *fleetEditor) rotate() error {
+	s := e.ships[e.selected]
+	s.horizontal = !s.horizontal
+	if s.placed && !e.fits(e.selected, s) {
+		return fmt.Errorf("there is no room to rotate the ship")
+	}
+	e.change(func() { e.ships[e.selected] = s })
+	return nil
+}
+
+// remove takes the selected ship off the board
+func (e *fleetEditor) remove() {
+	if e.ships[e.selected].placed {
+		e.change(func() { e.ships[e.selected].placed = false })
+	}
+}
+
+// clear takes all ships off the board
+func (e *fleetEditor) clear() {
+	e.change(func() {
+		for i := range e.ships {
+			e.ships[i].placed = false
+		}
+		e.selected = 0
+	})
+}
+
+// autoFill places all remaining ships at random, the placed ships stay where they are
+func (e *fleetEditor) autoFill(rng *rand.Rand) error {
+	for attempt := 0; attempt < autoFillAttempts; attempt++ {
+		filled := *e
+		if filled.fillRandomly(rng) {
+			e.change(func() { e.ships = filled.ships })
+			return nil
+		}
+	}
+	return fmt.Errorf("the remaining ships do not fit, move some ships and try again")
+}
+
+// fillRandomly tries to place every unplaced ship once at a random free position, longest first
+func (e *fleetEditor) fillRandomly(rng *rand.Rand) bool {
+	for i := range e.ships {
+		if e.ships[i].placed {
+			continue
+		}
+		var options []editorShip
+		for x := 0; x < 10; x++ {
+			for y := 0; y < 10; y++ {
+				for _, horizontal := range []bool{true, false} {
+					s := editorShip{placed: true, x: x, y: y, horizontal: horizontal}
+					if e.fits(i, s) {
+						options = append(options, s)
+					}
+				}
+			}
+		}
+		if len(options) == 0 {
+			return false
+		}
+		e.ships[i] = options[rng.Intn(len(options))]
+	}
+	return true
+}
+
+// undoLast takes back the last change, false when there is nothing to undo
+func (e *fleetEditor) undoLast() bool {
+	if len(e.undo) == 0 {
+		return false
+	}
+	e.redo = append(e.redo, e.ships)
+	e.ships = e.undo[len(e.undo)-1]
+	e.undo = e.undo[:len(e.undo)-1]
+	return true
+}
+
+// redoLast applies again the last change taken back, false when there is nothing to redo
+func (e *fleetEditor) redoLast() bool {
+	if len(e.redo) == 0 {
+		return false
+	}
+	e.undo = append(e.undo, e.ships)
+	e.ships = e.redo[len(e.redo)-1]
+	e.redo = e.redo[:len(e.redo)-1]
+	return true
+}
+
+// complete checks if every ship is on the board, the editor never lets ships overlap or touch
+func (e *fleetEditor) complete() bool {
+	for _, s := range e.ships {
+		if !s.placed {
+			return false
+		}
+	}
+	return true
+}
+
+// coords returns the fields of all placed ships
+func (e *fleetEditor) coords() []string {
+	var coords []string
+	for i, s := range e.ships {
+		if !s.placed {
+			continue
+		}
+		cells, _ := shipCells(s, appState.FleetLengths[i])
+		for _, c := range cells {
+			coords = append(coords, appState.FormatCoord(c[0], c[1]))
+		}
+	}
+	return coords
+}
+
+// states returns the board with placed ships, the selected ship is shown as hit to stand out
+func (e *fleetEditor) states() [10][10]gui.State {
+	var states [10][10]gui.State
+	for x := range states {
+		for y := range states[x] {
+			states[x][y] = gui.Empty
+		}
+	}
+	for i, s := range e.ships {
+		if !s.placed {
+			continue
+		}
+		cells, _ := shipCells(s, appState.FleetLengths[i])
+		for _, c := range cells {
+			states[c[0]][c[1]] = gui.Ship
+			if i == e.selected {
+				states[c[0]][c[1]] = gui.Hit
+			}
+		}
+	}
+	return states
+}
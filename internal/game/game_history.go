@@ -0,0 +1,154 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/history"
+	"battleships/internal/httpClient"
+	"sync"
+	"time"
+)
+
+// gameRecorder represents the record of the game being played, saved to the history when it ends
+type gameRecorder struct {
+	store    *history.Store  // Finished games, nil when the config directory is unknown
+	game     history.Game    // Game being recorded
+	last     *gameSummary    // Summary of the last finished game, nil until a game ends
+	series   *history.Series // Series the finished games count in, nil outside of a series
+	profiled bool            // Information if the opponent's statistics and profile were already shown
+	mu       sync.Mutex      // Mutex for record access synchronization
+}
+
+// newGameRecorder creates a recorder saving games to the history in the user's config directory
+func newGameRecorder() gameRecorder {
+	path, err := history.DefaultPath()
+	if err != nil {
+		return gameRecorder{}
+	}
+	return gameRecorder{store: history.Open(path)}
+}
+
+// recording reports whether the current game is recorded, hotseat games have no opponent to profile
+func (a *App) recording() bool {
+	return !a.hotseat
+}
+
+// startRecording starts the record of a new game and hides what was shown about the previous opponent
+func (a *App) startRecording() {
+	if !a.recording() {
+		return
+	}
+	a.history.mu.Lock()
+	a.history.game = history.Game{}
+	a.history.last = nil
+	a.history.profiled = false
+	a.history.mu.Unlock()
+	a.ui.showOpponentInfo(nil)
+}
+
+// recordShot adds the player's shot to the record
+func (a *App) recordShot(shot, result string) {
+	if !a.recording() {
+		return
+	}
+	a.history.mu.Lock()
+	defer a.history.mu.Unlock()
+	a.history.game.Shots = append(a.history.game.Shots, history.Shot{Coord: shot, Result: result})
+}
+
+// recordStatus keeps the players and the opponent's shots, and shows the opponent's statistics and profile once the opponent is known
+func (a *App) recordStatus(status httpClient.GameStatus) {
+	if !a.recording() || status.Opponent == "" {
+		return
+	}
+	a.history.mu.Lock()
+	defer a.history.mu.Unlock()
+	if a.history.game.Started.IsZero() {
+		a.history.game.Started = time.Now()
+	}
+	a.history.game.Nick = status.Nick
+	a.history.game.Opponent = status.Opponent
+	a.history.game.OppShots = append([]string(nil), status.OppShots...)
+	if !a.history.profiled {
+		a.history.profiled = true
+		go a.showOpponent(status.Opponent)
+	}
+}
+
+// saveGame completes the record with the result and the boards, adds it to the history and keeps its summary
+func (a *App) saveGame(status httpClient.GameStatus) {
+	if !a.recording() {
+		return
+	}
+	a.recordStatus(status)
+	a.history.mu.Lock()
+	g := a.history.game
+	a.history.game = history.Game{}
+	a.history.mu.Unlock()
+	if g.Opponent == "" {
+		return
+	}
+
+	summary := &gameSummary{playerBoard: a.game.GetPlayerBoard(), oppBoard: a.game.GetOpponentBoard()}
+	g.Ended = time.Now()
+	g.Result = status.LastGameStatus
+	g.Fleet = fieldsWith(summary.playerBoard, appState.Ship, appState.Hit, appState.Sunk)
+	g.OppShips = fieldsWith(summary.oppBoard, appState.Hit, appState.Sunk)
+	summary.record = g
+	a.history.mu.Lock()
+	var series *history.Series
+	var seriesErr error
+	if a.history.series != nil && a.history.series.Counts(g) {
+		if seriesErr = a.history.series.Add(g); seriesErr == nil {
+			summary.series = a.history.series.Score()
+			saved := *a.history.series
+			series = &saved
+		}
+	}
+	a.history.last = summary
+	a.history.mu.Unlock()
+	if seriesErr != nil {
+		a.ui.showWarning(seriesErr.Error())
+	}
+
+	if a.history.store == nil {
+		return
+	}
+	var err error
+	if series != nil {
+		err = a.history.store.SaveSeries(*series)
+	} else {
+		err = a.history.store.Add(g)
+	}
+	if err != nil {
+		a.ui.showWarning(err.Error())
+	}
+}
+
+// gameEnded reports whether the last game ended and its summary was not shown yet
+func (a *App) gameEnded() bool {
+	a.history.mu.Lock()
+	defer a.history.mu.Unlock()
+	return a.history.last != nil
+}
+
+// lastSummary returns the summary of the last game and forgets it, false when the game did not end
+func (a *App) lastSummary() (*gameSummary, bool) {
+	a.history.mu.Lock()
+	defer a.history.mu.Unlock()
+	s := a.history.last
+	a.history.last = nil
+	return s, s != nil
+}
+
+// fieldsWith returns the fields of the board in one of the given states
+func fieldsWith(board [10][10]string, states ...string) []string {
+	var fields []string
+	for x := range board {
+		for y := range board[x] {
+			if contains(states, board[x][y]) {
+				fields = append(fields, appState.FormatCoord(x, y))
+			}
+		}
+	}
+	return fields
+}
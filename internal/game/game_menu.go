@@ -2,9 +2,10 @@ package game
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/fatih/color"
-	"github.com/manifoldco/promptui"
+	"io"
 	"os"
 	"os/exec"
 )
@@ -26,8 +27,13 @@ func (a *App) DisplayRulesAndDescription() {
 	fmt.Println("Choose a nickname to save your progress. Enter your nickname and description in option 3 in the menu, otherwise you will receive a random nickname and description.")
 	fmt.Println("Choose the game mode (with a bot or a real opponent), then choose whether you want to choose the fields with ships yourself, or the game will do it for you.")
 	fmt.Println("During the game, to attack the opponent, you have to click on his board.")
+	fmt.Println("You can also use the keyboard: move the cursor with arrows and press Enter, or type a field like E7 and press Enter. While placing ships, press r to rotate the selected ship, u to undo, o to auto-fill the remaining ships and s to confirm the fleet.")
 	fmt.Println("When you hit correctly, the board will show the symbol H and allow you to shoot again.")
 	fmt.Println("You have 60 seconds to take a shot, otherwise you lose.")
+	fmt.Println("You can turn on the turn-timer safeguard in the menu, it fires automatically when your time is about to run out.")
+	fmt.Println("Start the game with -bot \"command\" to let your own bot program choose placements and shots, see internal/botEngine for the protocol.")
+	fmt.Println("Finished games are kept in your config directory. The side panel shows the opponent's ranking, your head-to-head record and, when you meet them again, where their ships used to be and where they like to shoot first.")
+	fmt.Println("A series is won by the first player to win most of its 3, 5 or 7 games, the score is shown at the top of the screen.")
 	fmt.Println("The game will be won by the person who first sinks all the opponent's ships.")
 	fmt.Println("You can leave the game using the keyboard shortcut \"ctrl + c\"")
 }
@@ -50,20 +56,23 @@ func (a *App) DisplayMenu(ctx context.Context) {
 			"Show game rules and application description",
 			"Start singleplayer game with bot",
 			"Start multiplayer game",
+			"Play a series (best of 3, 5 or 7)",
+			"Start hotseat game for two players on this computer",
+			"Start LAN game (host or join)",
 			"Enter player information (nickname and description)",
 			"Show top 10 best players",
 			"Show player statistics",
 			"Show player lobby",
+			"Configure turn-timer safeguard",
+			"Share or load fleets and boards (codes and grid files)",
 			"Exit",
 			"Return to menu",
 		}
 
-		prompt := promptui.Select{
-			Label: color.GreenString("Welcome to Battleships! Choose an option:"),
-			Items: menuItems,
+		result, err := a.prompts.choose(color.GreenString("Welcome to Battleships! Choose an option:"), menuItems)
+		if errors.Is(err, io.EOF) {
+			a.ExitGame()
 		}
-
-		_, result, err := prompt.Run()
 		if err != nil {
 			color.Red("Error executing command %v\n", err)
 			continue
@@ -71,11 +80,7 @@ func (a *App) DisplayMenu(ctx context.Context) {
 
 		a.handleMenuSelection(result, ctx)
 
-		promptContinue := promptui.Prompt{
-			Label:     color.YellowString("Press any key to continue"),
-			AllowEdit: false,
-		}
-		_, _ = promptContinue.Run()
+		_, _ = a.prompts.ask(color.YellowString("Press any key to continue"), "", nil)
 	}
 }
 
@@ -88,6 +93,12 @@ func (a *App) handleMenuSelection(selection string, ctx context.Context) {
 		a.InitGameVersusBot(ctx)
 	case "Start multiplayer game":
 		a.InitGameVersusPlayer(ctx)
+	case "Play a series (best of 3, 5 or 7)":
+		a.InitSeries(ctx)
+	case "Start hotseat game for two players on this computer":
+		a.InitHotseatGame(ctx)
+	case "Start LAN game (host or join)":
+		a.InitLanGame(ctx)
 	case "Enter player information (nickname and description)":
 		a.EnterPlayerInfo()
 	case "Show top 10 best players":
@@ -96,6 +107,10 @@ func (a *App) handleMenuSelection(selection string, ctx context.Context) {
 		a.GetPlayerStats()
 	case "Show player lobby":
 		a.PrintLobby()
+	case "Configure turn-timer safeguard":
+		a.ConfigureSafeguard()
+	case "Share or load fleets and boards (codes and grid files)":
+		a.ShareCodes()
 	case "Exit":
 		a.ExitGame()
 	default:
@@ -106,6 +121,7 @@ func (a *App) handleMenuSelection(selection string, ctx context.Context) {
 // ExitGame ends the game
 func (a *App) ExitGame() {
 	color.Green("See you next time!")
+	a.CloseBot()
 	os.Exit(0)
 }
 
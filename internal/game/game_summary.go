@@ -0,0 +1,198 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/history"
+	"context"
+	"fmt"
+	gui "github.com/grupawp/warships-gui/v2"
+	"strings"
+	"time"
+)
+
+// Choices offered on the summary screen after a game
+const (
+	summaryRematch       = "Rematch"
+	summaryRematchLayout = "Rematch with the same layout"
+	summaryReplay        = "Save replay"
+	summaryMenu          = "Back to menu"
+)
+
+// summaryChoices are the choices of the summary screen in the order they are shown
+var summaryChoices = []string{summaryRematch, summaryRematchLayout, summaryReplay, summaryMenu}
+
+// gameSummary represents the outcome of a finished game
+type gameSummary struct {
+	record      history.Game   // Record of the game, saved as the replay
+	playerBoard [10][10]string // Player's board at the end of the game
+	oppBoard    [10][10]string // Opponent's board at the end of the game
+	series      string         // Score of the series the game counts in, empty outside of a series
+	fairness    string         // Check of the opponent's answers against their revealed fleet, empty outside of LAN games
+}
+
+// headline describes the result of the game
+func (s *gameSummary) headline() string {
+	switch s.record.Result {
+	case history.Win:
+		return fmt.Sprintf("You won against %s!", s.record.Opponent)
+	case history.Lose:
+		return fmt.Sprintf("You lost against %s.", s.record.Opponent)
+	default:
+		return fmt.Sprintf("The game against %s ended.", s.record.Opponent)
+	}
+}
+
+// stats describes the shots and the duration of the game in short lines
+func (s *gameSummary) stats() []string {
+	hits, streak, longest := 0, 0, 0
+	for _, shot := range s.record.Shots {
+		if shot.Result == appState.ResultMiss {
+			streak = 0
+			continue
+		}
+		hits++
+		streak++
+		longest = max(longest, streak)
+	}
+	return []string{
+		fmt.Sprintf("Opponent: %s", s.record.Opponent),
+		fmt.Sprintf("Shots fired: %d, hits: %d", len(s.record.Shots), hits),
+		fmt.Sprintf("Accuracy: %s %%", getAccuracy(hits, len(s.record.Shots))),
+		fmt.Sprintf("Shots received: %d", len(s.record.OppShots)),
+		fmt.Sprintf("Longest hit streak: %d", longest),
+		fmt.Sprintf("Time used: %s", s.record.Ended.Sub(s.record.Started).Round(time.Second)),
+	}
+}
+
+// playAgain shows the summary of the game that just ended and reports whether the player wants to play again,
+// with the rematch to start or nil for a new game. Without a summary, when the player left before the end,
+// it only asks about playing again.
+func (a *App) playAgain(ctx context.Context) (*rematchPlan, bool) {
+	s, ok := a.lastSummary()
+	if !ok {
+		choice, err := a.prompts.choose("Do you want to play again?", []string{"Yes", "No"})
+		if err != nil {
+			fmt.Printf("Error executing command %v\n", err)
+			return nil, false
+		}
+		return nil, choice == "Yes"
+	}
+
+	for {
+		switch choice := a.showSummary(ctx, s, summaryChoices); choice {
+		case summaryRematch, summaryRematchLayout:
+			return newRematchPlan(s, choice == summaryRematchLayout), true
+		case summaryReplay:
+			a.saveReplay(s)
+		default:
+			return nil, false
+		}
+	}
+}
+
+// showSummary shows the summary with the screen matching the user interface and returns the chosen choice
+func (a *App) showSummary(ctx context.Context, s *gameSummary, choices []string) string {
+	if _, ok := a.ui.(*Gui); ok {
+		return summaryScreen(ctx, s, choices)
+	}
+	return a.promptSummary(s, choices)
+}
+
+// saveReplay asks for a path and saves the record of the game there
+func (a *App) saveReplay(s *gameSummary) {
+	path, ok := a.promptPath("Path of the replay file")
+	if !ok {
+		return
+	}
+	if err := history.SaveReplay(path, s.record); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Replay saved to %s\n", path)
+}
+
+// promptSummary prints the summary and asks what to do next
+func (a *App) promptSummary(s *gameSummary, choices []string) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\n%s\n", s.headline()))
+	if s.series != "" {
+		b.WriteString(s.series + "\n")
+	}
+	b.WriteString(fmt.Sprintf("    %-23s    %s\n", "Your board", "Opponent's board"))
+	b.WriteString("    A B C D E F G H I J        A B C D E F G H I J\n")
+	for y := 0; y < 10; y++ {
+		b.WriteString(fmt.Sprintf("%3d %s    %3d %s\n", y+1, boardRow(s.playerBoard, y), y+1, boardRow(s.oppBoard, y)))
+	}
+	for _, line := range s.stats() {
+		b.WriteString(line + "\n")
+	}
+	if s.fairness != "" {
+		b.WriteString(s.fairness + "\n")
+	}
+	fmt.Print(b.String())
+
+	choice, err := a.prompts.choose("What next?", choices)
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return ""
+	}
+	return choice
+}
+
+// summaryScreen shows the summary with both final boards until the player clicks a choice, ctrl+c returns no choice
+func summaryScreen(ctx context.Context, s *gameSummary, choices []string) string {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	screen := gui.NewGUI(false)
+	screen.Draw(gui.NewText(1, 0, "Press ctrl+c to go back to the menu", nil))
+	screen.Draw(gui.NewText(1, 2, s.headline(), nil))
+	screen.Draw(gui.NewText(1, 3, s.series, nil))
+	boards := []struct {
+		x      int
+		label  string
+		states [10][10]string
+	}{
+		{1, "Your board", s.playerBoard},
+		{50, "Opponent's board", s.oppBoard},
+	}
+	for _, b := range boards {
+		board := gui.NewBoard(b.x, 5, nil)
+		board.SetStates(mapStatesToGuiMarks(b.states))
+		marks := newBoardOverlay(b.x, 5)
+		marks.update(b.states)
+		screen.Draw(board)
+		marks.draw(screen)
+		screen.Draw(gui.NewText(b.x, 27, b.label, nil))
+	}
+	for i, line := range s.stats() {
+		screen.Draw(gui.NewText(100, 5+i, line, nil))
+	}
+	if s.fairness != "" {
+		for i, line := range strings.Split(s.fairness, "\n") {
+			screen.Draw(gui.NewText(1, 29+i, line, nil))
+		}
+	}
+	actions := make(chan string, 1)
+	for i, choice := range choices {
+		screen.Draw(newButton(100, 13+2*i, choice, choice, actions))
+	}
+
+	chosen := make(chan string, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case choice := <-actions:
+			chosen <- choice
+			cancel()
+		}
+	}()
+	screen.Start(ctx, nil)
+
+	select {
+	case choice := <-chosen:
+		return choice
+	default:
+		return ""
+	}
+}
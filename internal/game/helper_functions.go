@@ -1,17 +1,14 @@
 package game
 
 import (
+	"battleships/internal/appState"
 	gui "github.com/grupawp/warships-gui/v2"
 )
 
-// mapToState converts coordinates to board coordinates
-func mapToState(cord string) (int, int) {
-	if len(cord) > 2 {
-		return int(cord[0] - 65), 9
-	}
-	x := int(cord[0] - 65)
-	y := int(cord[1] - 49)
-	return x, y
+// isValidCoord checks if the coordinate is a field of the board, from A1 to J10
+func isValidCoord(coord string) bool {
+	_, _, err := appState.ParseCoord(coord)
+	return err == nil
 }
 
 // isValidPlacement checks if the ship placement is valid
@@ -20,11 +17,11 @@ func isValidPlacement(coords []string) bool {
 		return false
 	}
 
-	x, y := mapToState(coords[0])
+	x, y, _ := appState.ParseCoord(coords[0])
 	directions := [][]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} // Right, Left, Down, Up
 
 	for i := 1; i < len(coords); i++ {
-		xi, yi := mapToState(coords[i])
+		xi, yi, _ := appState.ParseCoord(coords[i])
 
 		if xi != x && yi != y {
 			return false // Invalid coordinate placement (not horizontal or vertical)
@@ -52,7 +49,7 @@ func isValidPlacement(coords []string) bool {
 // touchesAnotherShip checks if the ship touches another ship
 func touchesAnotherShip(coords []string, states [10][10]gui.State) bool {
 	for _, v := range coords {
-		x, y := mapToState(v)
+		x, y, _ := appState.ParseCoord(v)
 		if hasShipAround(x, y, states) {
 			return true
 		}
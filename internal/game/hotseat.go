@@ -0,0 +1,116 @@
+package game
+
+import (
+	"battleships/internal/httpClient"
+	"battleships/internal/localGame"
+	"context"
+	"fmt"
+	tl "github.com/grupawp/termloop"
+	gui "github.com/grupawp/warships-gui/v2"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// hotseatTurnDelay is the time the player sees the result of the last shot before the screen closes
+const hotseatTurnDelay = 1500 * time.Millisecond
+
+// InitHotseatGame starts a game for two players sharing one keyboard, played by the in-process rules
+func (a *App) InitHotseatGame(ctx context.Context) {
+	var nicks [2]string
+	for i := range nicks {
+		nick, err := a.prompts.ask(fmt.Sprintf("Enter the nickname of player %d", i+1), fmt.Sprintf("Player %d", i+1), nil)
+		if err != nil {
+			fmt.Printf("Error executing command %v\n", err)
+			return
+		}
+		nicks[i] = nick
+	}
+
+	match := localGame.NewMatch(localGame.DefaultTurnTime, rand.New(rand.NewSource(time.Now().UnixNano())))
+	games := [2]*httpClient.Game{
+		httpClient.NewLocalGame(match.Seat(0)),
+		httpClient.NewLocalGame(match.Seat(1)),
+	}
+
+	// The game loop works on a.game, it is switched to the player whose turn it is
+	mainGame := a.game
+	a.hotseat = true
+	defer func() {
+		a.game = mainGame
+		a.hotseat = false
+	}()
+
+	for i, g := range games {
+		a.game = g
+		g.UpdatePlayerInfo(nicks[i], fmt.Sprintf("Hotseat player %d", i+1))
+		a.passKeyboard(ctx, nicks[i], "place your ships")
+
+		answer, err := a.prompts.choose(fmt.Sprintf("%s, do you want to place your ships?", nicks[i]), placementChoices)
+		if err != nil {
+			fmt.Printf("Error executing command %v\n", err)
+			return
+		}
+		a.placeShipsByChoice(ctx, answer)
+		nick, desc := g.GetPlayerInfo()
+		g.StartGame(nick, desc, "", g.GetPlayerCoords(), false)
+		board, err := g.LoadPlayerBoard()
+		if err != nil {
+			fmt.Printf("Error starting the game %v\n", err)
+			return
+		}
+		_, _ = g.SetPlayerBoard(board.Board)
+	}
+
+	for {
+		if winner, ended := match.Result(); ended {
+			a.ui.clear()
+			fmt.Printf("%s won the hotseat game!\n", nicks[winner])
+			return
+		}
+		turn := match.Turn()
+		a.game = games[turn]
+		a.ui.clear()
+		a.passKeyboard(ctx, nicks[turn], "take your turn")
+		match.StartTurn()
+		a.playHotseatTurn(ctx)
+	}
+}
+
+// playHotseatTurn runs the game loop until the turn passes to the other player or the game ends
+func (a *App) playHotseatTurn(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(8)
+	a.runGameRoutines(ctx, cancel, &wg)
+
+	for {
+		a.ui.start(ctx)
+		if ctx.Err() != nil {
+			break
+		}
+		// The player closed the screen in the middle of the turn
+		answer, err := a.prompts.choose("Leave the hotseat game? Your opponent wins.", []string{"Yes", "No"})
+		if err != nil || answer == "Yes" {
+			a.game.AbortGame()
+			break
+		}
+	}
+	cancel()
+	wg.Wait()
+}
+
+// passKeyboard hides the boards until the next player confirms they sit at the keyboard
+func (a *App) passKeyboard(ctx context.Context, nick, action string) {
+	msg := fmt.Sprintf("Pass the keyboard to %s to %s.", nick, action)
+	if t, ok := a.ui.(*TextUI); ok {
+		t.passKeyboard(ctx, msg)
+		return
+	}
+	screen := gui.NewGUI(false)
+	screen.Draw(gui.NewText(10, 10, msg, nil))
+	screen.Draw(gui.NewText(10, 12, "Press Enter when ready.", nil))
+	enter := tl.KeyEnter
+	screen.Start(ctx, &enter)
+}
@@ -0,0 +1,222 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	tl "github.com/grupawp/termloop"
+	gui "github.com/grupawp/warships-gui/v2"
+	"strings"
+	"sync"
+)
+
+// Colors of the keyboard cursor
+var (
+	cursorColor        = tl.RgbTo256Color(230, 200, 40)
+	invalidCursorColor = tl.RgbTo256Color(172, 65, 66)
+	cursorTextColor    = tl.RgbTo256Color(21, 21, 21)
+)
+
+// keyboardInput lets the player choose fields of a board with the keyboard.
+// Arrow keys move a cursor, Enter or space picks the field under it,
+// and a typed coordinate like E7 followed by Enter picks that field directly.
+// Other keys are passed on as commands.
+type keyboardInput struct {
+	id         uuid.UUID
+	x, y       int           // Top left corner of the board the cursor moves over
+	cursorX    int           // Column of the cursor
+	cursorY    int           // Row of the cursor
+	buffer     string        // Coordinate typed so far
+	message    string        // Information about the last invalid input
+	shipLength int           // Length of the ship being placed, 0 when shooting
+	horizontal bool          // Orientation of the ship being placed
+	ch         chan []string // Fields picked with the keyboard
+	commands   chan rune     // Keys that are not a part of a coordinate, Tab is sent as '\t'
+	mu         sync.Mutex
+}
+
+// newKeyboardInput creates keyboard input for a board with its top left corner at x and y
+func newKeyboardInput(x, y int) *keyboardInput {
+	return &keyboardInput{
+		id:         uuid.New(),
+		x:          x,
+		y:          y,
+		horizontal: true,
+		ch:         make(chan []string, 1),
+		commands:   make(chan rune, 1),
+	}
+}
+
+// ID returns the identifier used by the GUI
+func (k *keyboardInput) ID() uuid.UUID {
+	return k.id
+}
+
+// Drawables returns the objects drawn by the GUI
+func (k *keyboardInput) Drawables() []tl.Drawable {
+	return []tl.Drawable{k}
+}
+
+// setShip switches the input to placing a ship of the given length and orientation, 0 switches it back to shooting
+func (k *keyboardInput) setShip(length int, horizontal bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.shipLength = length
+	k.horizontal = horizontal
+}
+
+// Tick handles key presses
+func (k *keyboardInput) Tick(ev tl.Event) {
+	if ev.Type != tl.EventKey {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	switch ev.Key {
+	case tl.KeyArrowUp:
+		k.moveCursor(0, -1)
+	case tl.KeyArrowDown:
+		k.moveCursor(0, 1)
+	case tl.KeyArrowLeft:
+		k.moveCursor(-1, 0)
+	case tl.KeyArrowRight:
+		k.moveCursor(1, 0)
+	case tl.KeyBackspace, tl.KeyBackspace2:
+		if len(k.buffer) > 0 {
+			k.buffer = k.buffer[:len(k.buffer)-1]
+		}
+	case tl.KeyEnter:
+		k.submit()
+	case tl.KeySpace:
+		k.pick()
+	case tl.KeyTab:
+		k.command('\t')
+	default:
+		k.typeRune(ev.Ch)
+	}
+}
+
+// typeRune handles typed characters
+func (k *keyboardInput) typeRune(ch rune) {
+	switch {
+	case ch >= 'a' && ch <= 'j', ch >= 'A' && ch <= 'J':
+		k.buffer = strings.ToUpper(string(ch))
+		k.message = ""
+	case ch >= '0' && ch <= '9' && len(k.buffer) > 0 && len(k.buffer) < 3:
+		k.buffer += string(ch)
+	case ch != 0 && k.buffer == "":
+		k.command(ch)
+	}
+}
+
+// command passes on a key that is not a part of a coordinate
+func (k *keyboardInput) command(ch rune) {
+	select {
+	case k.commands <- ch:
+	default:
+		// Nobody listens for commands or the previous one was not handled yet
+	}
+}
+
+// moveCursor moves the cursor without leaving the board
+func (k *keyboardInput) moveCursor(dx, dy int) {
+	k.cursorX = min(max(k.cursorX+dx, 0), 9)
+	k.cursorY = min(max(k.cursorY+dy, 0), 9)
+}
+
+// submit picks the typed field or the field under the cursor
+func (k *keyboardInput) submit() {
+	if k.buffer == "" {
+		k.pick()
+		return
+	}
+	coord := k.buffer
+	k.buffer = ""
+	if !isValidCoord(coord) {
+		k.message = fmt.Sprintf("%s is not a field of the board", coord)
+		return
+	}
+	k.message = ""
+	k.cursorX, k.cursorY, _ = appState.ParseCoord(coord)
+	k.pick()
+}
+
+// pick sends the fields under the cursor
+func (k *keyboardInput) pick() {
+	fields, ok := k.cursorFields()
+	if !ok {
+		k.message = "The ship does not fit here"
+		return
+	}
+	select {
+	case k.ch <- fields:
+	default:
+		// The previous choice was not handled yet, drop this one
+	}
+}
+
+// cursorFields returns the fields covered by the cursor
+func (k *keyboardInput) cursorFields() ([]string, bool) {
+	length := max(k.shipLength, 1)
+	var fields []string
+	for i := 0; i < length; i++ {
+		x, y := k.cursorX, k.cursorY+i
+		if k.horizontal {
+			x, y = k.cursorX+i, k.cursorY
+		}
+		if x > 9 || y > 9 {
+			return fields, false
+		}
+		fields = append(fields, appState.FormatCoord(x, y))
+	}
+	return fields, true
+}
+
+// Draw draws the cursor and the typed coordinate
+func (k *keyboardInput) Draw(s *tl.Screen) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	fields, ok := k.cursorFields()
+	bg := cursorColor
+	if !ok {
+		bg = invalidCursorColor
+	}
+	for _, f := range fields {
+		fx, fy, _ := appState.ParseCoord(f)
+		// Brackets around the field keep its own mark visible
+		s.RenderCell(k.x+(fx+1)*4, k.y+(fy+1)*2, &tl.Cell{Fg: cursorTextColor, Bg: bg, Ch: '['})
+		s.RenderCell(k.x+(fx+1)*4+2, k.y+(fy+1)*2, &tl.Cell{Fg: cursorTextColor, Bg: bg, Ch: ']'})
+	}
+
+	status := fmt.Sprintf("Field: %s_  %s", k.buffer, k.message)
+	for i, ch := range status {
+		s.RenderCell(k.x+i, k.y+25, &tl.Cell{Fg: cursorTextColor, Bg: tl.RgbTo256Color(208, 208, 208), Ch: ch})
+	}
+}
+
+// listenBoard blocks until fields are picked on the board with the mouse or the keyboard.
+// It returns nil when the context is done.
+func listenBoard(ctx context.Context, board *gui.Board, keyboard *keyboardInput) []string {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	clicks := make(chan string, 1)
+	go func() {
+		clicks <- board.Listen(ctx)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case coord := <-clicks:
+		if coord == "" {
+			return nil
+		}
+		return []string{coord}
+	case fields := <-keyboard.ch:
+		return fields
+	}
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"battleships/internal/httpClient"
+	"battleships/internal/peerGame"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultLanPort is the port offered when hosting or joining a LAN game
+const defaultLanPort = "7777"
+
+// revealTimeout limits the time of waiting for the opponent's fleet counted from the end of a LAN game
+const revealTimeout = 10 * time.Second
+
+// InitLanGame starts a game played directly with another client in the local network
+func (a *App) InitLanGame(ctx context.Context) {
+	mode, err := a.prompts.choose("Host a LAN game or join one?", []string{"Host", "Join"})
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return
+	}
+
+	defaultAddr := ":" + defaultLanPort
+	label := "Enter the address to listen on"
+	if mode == "Join" {
+		defaultAddr = "localhost:" + defaultLanPort
+		label = "Enter the address of the host"
+	}
+	addr, err := a.prompts.ask(label, defaultAddr, nil)
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return
+	}
+
+	var peer *peerGame.Peer
+	if mode == "Host" {
+		peer, err = peerGame.Host(addr)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("Waiting for the opponent on %s\n", peer.Addr())
+	} else {
+		peer = peerGame.Join(addr)
+	}
+	defer peer.Close()
+
+	a.playWithServer(ctx, httpClient.NewLocalGame(peer))
+	s, ok := a.lastSummary()
+	if !ok {
+		return
+	}
+	s.fairness = peer.Verify(revealTimeout).String()
+	for {
+		switch a.showSummary(ctx, s, []string{summaryReplay, summaryMenu}) {
+		case summaryReplay:
+			a.saveReplay(s)
+		default:
+			return
+		}
+	}
+}
+
+// playWithServer plays a single game against the given game instead of the remote API
+func (a *App) playWithServer(ctx context.Context, g *httpClient.Game) {
+	mainGame := a.game
+	a.game = g
+	defer func() {
+		a.game = mainGame
+	}()
+	nick, desc := mainGame.GetPlayerInfo()
+	g.UpdatePlayerInfo(nick, desc)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	answer, err := a.prompts.choose("Do you want to place your ships?", placementChoices)
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return
+	}
+	a.placeShipsByChoice(ctx, answer)
+
+	g.StartGame(nick, desc, "", g.GetPlayerCoords(), false)
+	board, err := g.LoadPlayerBoard()
+	if err != nil {
+		fmt.Printf("Error starting the game %v\n", err)
+		return
+	}
+	_, _ = g.SetPlayerBoard(board.Board)
+
+	var wg sync.WaitGroup
+	wg.Add(8)
+	a.runGameRoutines(ctx, cancel, &wg)
+	a.ui.start(ctx)
+
+	if ctx.Err() == nil {
+		abort, err := a.prompts.choose("Abort?", []string{"Yes", "No"})
+		if err != nil || abort == "Yes" {
+			g.AbortGame()
+		}
+	}
+	wg.Wait()
+	fmt.Printf("Game ended: %s\n", g.LastGameStatus())
+}
@@ -0,0 +1,34 @@
+package game
+
+import (
+	"battleships/internal/history"
+	"battleships/internal/httpClient"
+	"errors"
+	"fmt"
+)
+
+// showOpponent shows the opponent's statistics from the game server and their profile from the past games
+func (a *App) showOpponent(opponent string) {
+	var lines []string
+	stats, err := a.game.OpponentStats(opponent)
+	switch {
+	case err == nil:
+		lines = append(lines, statsLine(stats))
+	case !errors.Is(err, httpClient.ErrNoServerStats):
+		lines = append(lines, fmt.Sprintf("No statistics of %s", opponent))
+	}
+
+	var games []history.Game
+	if a.history.store != nil {
+		if games, err = a.history.store.Opponent(opponent); err != nil {
+			a.ui.showWarning(err.Error())
+		}
+	}
+	a.ui.showOpponentInfo(append(lines, history.NewProfile(opponent, games).Lines()...))
+}
+
+// statsLine describes the statistics of a player in a single line
+func statsLine(stats httpClient.GameStat) string {
+	return fmt.Sprintf("Rank %d, %d points, won %d of %d games (%s %%)",
+		stats.Rank, stats.Points, stats.Wins, stats.Games, getAccuracy(stats.Wins, stats.Games))
+}
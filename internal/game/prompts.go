@@ -0,0 +1,34 @@
+package game
+
+import (
+	"github.com/manifoldco/promptui"
+)
+
+// prompter asks the questions of the menu and of the game setup
+type prompter interface {
+	choose(label string, items []string) (string, error)                // Returns the chosen item
+	ask(label, def string, validate func(string) error) (string, error) // Returns the typed answer, def when nothing is typed
+}
+
+// terminalPrompts asks the questions with promptui, it needs a terminal in raw mode
+type terminalPrompts struct{}
+
+// choose shows a list of items to pick from with arrows and Enter
+func (terminalPrompts) choose(label string, items []string) (string, error) {
+	prompt := promptui.Select{
+		Label: label,
+		Items: items,
+	}
+	_, choice, err := prompt.Run()
+	return choice, err
+}
+
+// ask reads an answer with promptui, validate may be nil
+func (terminalPrompts) ask(label, def string, validate func(string) error) (string, error) {
+	prompt := promptui.Prompt{
+		Label:    label,
+		Default:  def,
+		Validate: validate,
+	}
+	return prompt.Run()
+}
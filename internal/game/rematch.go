@@ -0,0 +1,64 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// challengeTimeout is the time a challenged opponent has to accept before the player waits in the lobby
+const challengeTimeout = 60 * time.Second
+
+// rematchPlan represents what a rematch reuses from the last game, the player's profile is reused anyway
+type rematchPlan struct {
+	opponent string   // Opponent of the last game, challenged again
+	layout   []string // Player's fleet in the last game, nil to place the ships again
+}
+
+// newRematchPlan creates a rematch against the opponent of the summarised game
+func newRematchPlan(s *gameSummary, sameLayout bool) *rematchPlan {
+	plan := &rematchPlan{opponent: s.record.Opponent}
+	if sameLayout {
+		plan.layout = s.record.Fleet
+	}
+	return plan
+}
+
+// challenge challenges the opponent to a game, when the challenge fails or they do not accept in time the player waits in the lobby instead
+func (a *App) challenge(ctx context.Context, opponent, nick, desc string, coords []string) {
+	fmt.Printf("Challenging %s...\n", opponent)
+	err := a.game.StartGame(nick, desc, opponent, coords, false)
+	switch {
+	case err != nil:
+		fmt.Printf("Error challenging %s %v\n", opponent, err)
+	case a.waitForOpponent(ctx, challengeTimeout):
+		return
+	default:
+		fmt.Printf("%s did not accept the challenge\n", opponent)
+		a.game.AbortGame()
+	}
+	fmt.Println("Waiting in the lobby instead")
+	if err := a.game.StartGame(nick, desc, "", coords, false); err != nil {
+		fmt.Printf("Error starting the game %v\n", err)
+	}
+}
+
+// waitForOpponent polls the game status until the game is in progress, false when the time runs out first
+func (a *App) waitForOpponent(ctx context.Context, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+			status, err := a.game.GetGameStatus()
+			if err == nil && status.GameStatus == "game_in_progress" {
+				return true
+			}
+		}
+	}
+}
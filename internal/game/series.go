@@ -0,0 +1,186 @@
+package game
+
+import (
+	"battleships/internal/history"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// Choices offered on the summary screen between the games of a series
+const (
+	seriesNext  = "Next game"
+	seriesLeave = "Leave the series"
+)
+
+// Opponents a series can be played against
+const (
+	seriesPlayer = "Another player"
+	seriesBot    = "Server bot"
+)
+
+// seriesSetup represents the choices made before a series
+type seriesSetup struct {
+	bot       bool   // Information if the series is played against the server bot
+	bestOf    int    // Number of games the series is played over
+	opponent  string // Player challenged in the first game, empty to wait in the lobby
+	alternate bool   // Information if the player challenges and waits in the lobby in turns
+}
+
+// InitSeries plays a best-of-N series against the same player or the server bot
+func (a *App) InitSeries(parent context.Context) {
+	setup, ok := a.promptSeriesSetup()
+	if !ok {
+		return
+	}
+	nick, desc := a.game.GetPlayerInfo()
+	series, err := history.NewSeries(nick, setup.opponent, setup.bestOf)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	series.Bot = setup.bot
+	a.history.mu.Lock()
+	a.history.series = series
+	a.history.mu.Unlock()
+	defer func() {
+		a.history.mu.Lock()
+		a.history.series = nil
+		a.history.mu.Unlock()
+		a.ui.showHeader("")
+	}()
+
+	challenger := setup.opponent != ""
+	for {
+		a.history.mu.Lock()
+		score, opponent := series.Score(), series.Opponent
+		a.history.mu.Unlock()
+		a.ui.showHeader(score)
+
+		ctx, cancel := context.WithCancel(parent)
+		if !a.choosePlacement(ctx, nil) {
+			cancel()
+			return
+		}
+		coords := a.game.GetPlayerCoords()
+		switch {
+		case setup.bot:
+			a.game.StartGame(nick, desc, "", coords, true)
+		case challenger && opponent != "":
+			a.challenge(ctx, opponent, nick, desc, coords)
+		default:
+			a.game.StartGame(nick, desc, "", coords, false)
+		}
+		a.playSeriesGame(ctx, cancel)
+		if setup.alternate {
+			challenger = !challenger
+		}
+
+		if !a.seriesGameEnded(parent, series) {
+			return
+		}
+	}
+}
+
+// playSeriesGame shows a started game of a series until it ends or the player leaves it
+func (a *App) playSeriesGame(ctx context.Context, cancel context.CancelFunc) {
+	board, err := a.game.LoadPlayerBoard()
+	if err != nil {
+		fmt.Printf("Error starting the game %v\n", err)
+		cancel()
+		return
+	}
+	_, _ = a.game.SetPlayerBoard(board.Board)
+
+	var wg sync.WaitGroup
+	wg.Add(8)
+	a.runGameRoutines(ctx, cancel, &wg)
+	a.ui.start(ctx)
+
+	if !a.gameEnded() {
+		abort, err := a.prompts.choose("Abort?", []string{"Yes", "No"})
+		if err != nil || abort == "Yes" {
+			a.game.AbortGame()
+		}
+	}
+	wg.Wait()
+	cancel()
+}
+
+// seriesGameEnded shows the summary of the last game of the series and reports whether the series goes on
+func (a *App) seriesGameEnded(ctx context.Context, series *history.Series) bool {
+	s, ok := a.lastSummary()
+	if !ok {
+		choice, err := a.prompts.choose("You left the game before it ended, it does not count. Continue the series?", []string{"Yes", "No"})
+		if err != nil {
+			fmt.Printf("Error executing command %v\n", err)
+			return false
+		}
+		return choice == "Yes"
+	}
+
+	a.history.mu.Lock()
+	over, result, score := series.Over(), series.Result(), series.Score()
+	a.history.mu.Unlock()
+	if s.series == "" {
+		s.series = fmt.Sprintf("The game against %s does not count. %s", s.record.Opponent, score)
+	}
+	choices := []string{seriesNext, summaryReplay, seriesLeave}
+	if over {
+		outcome := "lost"
+		if result == history.Win {
+			outcome = "won"
+		}
+		s.series = fmt.Sprintf("You %s the series. %s", outcome, score)
+		choices = []string{summaryReplay, summaryMenu}
+	}
+
+	for {
+		switch a.showSummary(ctx, s, choices) {
+		case seriesNext:
+			return true
+		case summaryReplay:
+			a.saveReplay(s)
+		default:
+			return false
+		}
+	}
+}
+
+// promptSeriesSetup asks for the opponent and the length of a series
+func (a *App) promptSeriesSetup() (seriesSetup, bool) {
+	var setup seriesSetup
+	opponent, err := a.prompts.choose("Who do you want to play the series against?", []string{seriesPlayer, seriesBot})
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return setup, false
+	}
+	setup.bot = opponent == seriesBot
+
+	lengths := make([]string, len(history.SeriesLengths))
+	for i, n := range history.SeriesLengths {
+		lengths[i] = strconv.Itoa(n)
+	}
+	length, err := a.prompts.choose("Best of how many games?", lengths)
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return setup, false
+	}
+	setup.bestOf, _ = strconv.Atoi(length)
+	if setup.bot {
+		return setup, true
+	}
+
+	if setup.opponent, err = a.prompts.ask("Enter opponent's nickname (or leave empty to wait in the lobby for the first game) ", "", nil); err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return setup, false
+	}
+	alternate, err := a.prompts.choose("Take turns challenging and waiting in the lobby?", []string{"Yes", "No"})
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return setup, false
+	}
+	setup.alternate = alternate == "Yes"
+	return setup, true
+}
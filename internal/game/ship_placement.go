@@ -1,73 +1,297 @@
 package game
 
 import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"battleships/internal/strategy"
+	"battleships/internal/tournament"
 	"context"
+	"errors"
 	"fmt"
 	gui "github.com/grupawp/warships-gui/v2"
-	"sync"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
 )
 
-// PlaceShips places ships on the board
-func (a *App) PlaceShips(ctx context.Context) {
-	var mutex sync.Mutex
+// Actions of the placement editor, the keys are the keyboard shortcuts
+var editorKeys = map[rune]string{
+	'r':  "rotate",
+	'x':  "remove",
+	'u':  "undo",
+	'y':  "redo",
+	'w':  "clear",
+	'o':  "fill",
+	'\t': "next",
+	's':  "confirm",
+	'v':  "score",
+}
+
+// scoreGames is the number of simulated games of every strategy when scoring a layout in the editor
+const scoreGames = 200
+
+// linePlacer places ships with typed lines, it is used by interfaces that can not show the placement editor
+type linePlacer interface {
+	readShips(ctx context.Context) ([]string, bool)                    // Returns the fields of the typed fleet
+	reviewLayout(ctx context.Context, rng *rand.Rand) ([]string, bool) // Returns the accepted random fleet
+}
 
-	// Map of ships to place: key is the length of the ship, value is the number of such ships
-	ships := map[int]int{
-		4: 1,
-		3: 2,
-		2: 3,
-		1: 4,
+// placeShips lets the bot or the player place ships with the current user interface
+func (a *App) placeShips(ctx context.Context) {
+	if a.botActive() && a.placeBotShips() {
+		return
 	}
+	if p, ok := a.ui.(linePlacer); ok {
+		if coords, ok := p.readShips(ctx); ok {
+			a.game.SetPlayerBoard(coords)
+		}
+		return
+	}
+	a.PlaceShips(ctx)
+}
 
-	currStates := [10][10]gui.State{} // Current state of the board
-	newStates := [10][10]gui.State{}  // New state of the board after placing a ship
-	var fullCoords []string           // Full list of coordinates of all ships
+// Answers to the question about placing ships
+const (
+	placeYes    = "Yes"
+	placeRandom = "Edit a random layout"
+	placeNo     = "No"
+)
+
+// placementChoices are the ways of placing ships offered before a game
+var placementChoices = []string{placeYes, placeRandom, placeNo}
+
+// placeShipsByChoice places ships the way the player chose, the server places them when the player declines
+func (a *App) placeShipsByChoice(ctx context.Context, answer string) {
+	switch answer {
+	case placeYes:
+		a.placeShips(ctx)
+	case placeRandom:
+		a.editRandomLayout(ctx)
+	}
+}
+
+// editRandomLayout generates a random fleet and lets the player adjust it before the game
+func (a *App) editRandomLayout(ctx context.Context) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	if p, ok := a.ui.(linePlacer); ok {
+		if coords, ok := p.reviewLayout(ctx, rng); ok {
+			a.game.SetPlayerBoard(coords)
+		}
+		return
+	}
 
-	board := gui.NewBoard(0, 0, nil)               // Creating a new board
-	hint := gui.NewText(50, 0, "Place ships", nil) // Hint for the player
-	invalid := gui.NewText(50, 1, "", nil)         // Error message
-	placeGui := gui.NewGUI(false)                  // Creating a new user interface
-	placeGui.Draw(board)
-	placeGui.Draw(hint)
+	editor := newFleetEditor()
+	if err := editor.load(localGame.RandomLayout(rng)); err != nil {
+		fmt.Printf("Error generating a layout %v\n", err)
+		return
+	}
+	if coords, ok := editFleet(ctx, editor); ok {
+		a.game.SetPlayerBoard(coords)
+	}
+}
+
+// PlaceShips opens the placement editor, the board changes only when the player confirms a complete fleet
+func (a *App) PlaceShips(ctx context.Context) bool {
+	coords, ok := editFleet(ctx, newFleetEditor())
+	if ok {
+		a.game.SetPlayerBoard(coords)
+	}
+	return ok
+}
+
+// placementEditorScreen represents the elements of the placement editor screen
+type placementEditorScreen struct {
+	board    *gui.Board         // Board with placed ships
+	keyboard *keyboardInput     // Keyboard cursor showing the selected ship
+	message  *gui.Text          // Result of the last action
+	ships    []*button          // Ships of the fleet, a click selects the ship
+	buttons  map[string]*button // Buttons of the actions
+}
+
+// editFleet shows the placement editor until the player confirms the fleet or leaves with ctrl+c
+func editFleet(ctx context.Context, editor *fleetEditor) ([]string, bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	placeGui := gui.NewGUI(false)
+	actions := make(chan string, 1)
+	screen := placementEditorScreen{
+		board:    gui.NewBoard(0, 0, nil),
+		keyboard: newKeyboardInput(0, 0),
+		message:  gui.NewText(50, 1, "", nil),
+		buttons:  make(map[string]*button),
+	}
+	placeGui.Draw(screen.board)
+	placeGui.Draw(screen.keyboard)
+	placeGui.Draw(gui.NewText(50, 0, "Place your fleet, then confirm it. Press ctrl+c to leave without changes.", nil))
+	placeGui.Draw(screen.message)
+	for i := range appState.FleetLengths {
+		b := newButton(50, 3+i, "", "ship:"+strconv.Itoa(i), actions)
+		screen.ships = append(screen.ships, b)
+		placeGui.Draw(b)
+	}
+	rows := [][][2]string{
+		{{"rotate", "Rotate (r)"}, {"remove", "Remove (x)"}, {"undo", "Undo (u)"}, {"redo", "Redo (y)"}},
+		{{"next", "Next ship (Tab)"}, {"clear", "Clear (w)"}, {"fill", "Auto-fill (o)"}},
+		{{"confirm", "Confirm (s)"}, {"score", "Score (v)"}},
+	}
+	for r, row := range rows {
+		x := 50
+		for _, action := range row {
+			b := newButton(x, 14+2*r, action[1], action[0], actions)
+			screen.buttons[action[0]] = b
+			placeGui.Draw(b)
+			x += len(action[1]) + 3
+		}
+	}
+	placeGui.Draw(gui.NewText(50, 21, "Click a field or press Enter to put the selected ship there.", nil))
+	placeGui.Draw(gui.NewText(50, 22, "Click a placed ship to select it, then click a field to move it.", nil))
+	screen.update(editor)
 
-	// Goroutine for placing ships on the board
+	confirmed := make(chan []string, 1)
 	go func() {
-		for k, v := range ships {
-			hint.SetText(fmt.Sprintf("Place %v ship(s) of length %v", v, k))
-			placeGui.Draw(hint)
-			for i := 0; i < v; i++ {
-				var coords []string
-				for j := 0; j < k; j++ {
-					coord := board.Listen(ctx)
-					coords = append(coords, coord)
-					x, y := mapToState(coord)
-
-					mutex.Lock()
-					newStates[x][y] = gui.Ship
-					mutex.Unlock()
-					board.SetStates(newStates)
+		picked := make(chan []string)
+		go func() {
+			for {
+				fields := listenBoard(ctx, screen.board, screen.keyboard)
+				if fields == nil {
+					return
 				}
-				mutex.Lock()
-				valid := isValidPlacement(coords) && !touchesAnotherShip(coords, currStates)
-				mutex.Unlock()
-				if valid {
-					invalid.SetText("")
-					currStates = newStates
-					fullCoords = append(fullCoords, coords...)
-				} else {
-					invalid.SetText("Invalid placement, try again")
-					placeGui.Draw(invalid)
-					for _, coord := range coords {
-						x, y := mapToState(coord)
-						newStates[x][y] = gui.Empty
-					}
-					board.SetStates(newStates)
-					i--
+				select {
+				case <-ctx.Done():
+					return
+				case picked <- fields:
 				}
 			}
+		}()
+
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for {
+			var err error
+			action, message := "", ""
+			select {
+			case <-ctx.Done():
+				return
+			case fields := <-picked:
+				err = pickField(editor, fields[0])
+			case key := <-screen.keyboard.commands:
+				action = editorKeys[key]
+			case action = <-actions:
+			}
+			switch action {
+			case "":
+			case "score":
+				screen.message.SetText("Scoring the layout...")
+				message, err = scoreLayout(editor, rng)
+			default:
+				err = runEditorAction(editor, action, rng)
+			}
+			if err == errConfirmed {
+				confirmed <- editor.coords()
+				cancel()
+				return
+			}
+			if err != nil {
+				message = err.Error()
+			}
+			screen.message.SetText(message)
+			screen.update(editor)
 		}
-		hint.SetText("Finished placing ships. Press ctrl+c to save and return to the game!")
-		a.game.SetPlayerBoard(fullCoords)
 	}()
 	placeGui.Start(ctx, nil)
+
+	select {
+	case coords := <-confirmed:
+		return coords, true
+	default:
+		return nil, false
+	}
+}
+
+// errConfirmed signals that the player confirmed a complete fleet
+var errConfirmed = errors.New("fleet confirmed")
+
+// pickField selects the ship on the field, or puts the selected ship there when the field holds no other ship
+func pickField(editor *fleetEditor, field string) error {
+	x, y, _ := appState.ParseCoord(field)
+	if i := editor.shipAt(x, y); i >= 0 && i != editor.selected {
+		editor.selectShip(i)
+		return nil
+	}
+	return editor.place(x, y)
+}
+
+// scoreLayout simulates games against the fleet and describes how hard it is to sink
+func scoreLayout(editor *fleetEditor, rng *rand.Rand) (string, error) {
+	if !editor.complete() {
+		return "", fmt.Errorf("place all ships before scoring the layout")
+	}
+	report, err := tournament.AnalyzeLayout(tournament.LayoutConfig{
+		Layout:     editor.coords(),
+		Strategies: strategy.Names(),
+		Games:      scoreGames,
+		Seed:       rng.Int63(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return "Score: " + report.Summary(), nil
+}
+
+// runEditorAction runs an action chosen with a button or a key
+func runEditorAction(editor *fleetEditor, action string, rng *rand.Rand) error {
+	switch action {
+	case "rotate":
+		return editor.rotate()
+	case "remove":
+		editor.remove()
+	case "undo":
+		if !editor.undoLast() {
+			return fmt.Errorf("nothing to undo")
+		}
+	case "redo":
+		if !editor.redoLast() {
+			return fmt.Errorf("nothing to redo")
+		}
+	case "clear":
+		editor.clear()
+	case "fill":
+		return editor.autoFill(rng)
+	case "next":
+		editor.selectNext()
+	case "confirm":
+		if !editor.complete() {
+			return fmt.Errorf("place all ships before confirming")
+		}
+		return errConfirmed
+	default:
+		if i, err := strconv.Atoi(strings.TrimPrefix(action, "ship:")); err == nil {
+			editor.selectShip(i)
+		}
+	}
+	return nil
+}
+
+// update shows the fleet, the selected ship and whether it can be confirmed
+func (s placementEditorScreen) update(editor *fleetEditor) {
+	s.board.SetStates(editor.states())
+	selected := editor.ships[editor.selected]
+	s.keyboard.setShip(appState.FleetLengths[editor.selected], selected.horizontal)
+	for i, b := range s.ships {
+		ship := editor.ships[i]
+		mark, place := " ", "not placed"
+		if i == editor.selected {
+			mark = ">"
+		}
+		if ship.placed {
+			orientation := "vertical"
+			if ship.horizontal {
+				orientation = "horizontal"
+			}
+			place = fmt.Sprintf("at %s, %s", appState.FormatCoord(ship.x, ship.y), orientation)
+		}
+		b.setLabel(fmt.Sprintf("%s Ship of length %d %-18s", mark, appState.FleetLengths[i], place))
+	}
+	s.buttons["confirm"].setDisabled(!editor.complete())
 }
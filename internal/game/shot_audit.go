@@ -0,0 +1,40 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// auditLogFile is the name of the file collecting warnings about inconsistent shot results
+const auditLogFile = "battleships-audit.log"
+
+// auditShots warns the player when the results of the fired shots break the rules of the game
+func (a *App) auditShots() {
+	err := a.game.AuditShots()
+	if err == nil {
+		return
+	}
+	msg := fmt.Sprintf("Warning: %v", err)
+	a.ui.showWarning(msg)
+	logAuditWarning(msg)
+}
+
+// logAuditWarning appends the warning to the audit log in the user's config directory
+func logAuditWarning(msg string) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return
+	}
+	dir = filepath.Join(dir, "battleships")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(dir, auditLogFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	log.New(f, "", log.LstdFlags).Println(msg)
+}
@@ -3,20 +3,52 @@ package game
 import (
 	"battleships/internal/appState"
 	"battleships/internal/httpClient"
+	"battleships/internal/strategy"
+	"context"
 	"sync"
+	"time"
 
 	gui "github.com/grupawp/warships-gui/v2"
 )
 
 // App represents the main structure of the application
 type App struct {
-	gui                *Gui                       // Game user interface
+	ui                 frontend                   // Game user interface
+	prompts            prompter                   // Questions of the menu and of the game setup
 	game               *httpClient.Game           // Game object
 	playerShotsChannel chan string                // Channel for player shots communication
 	gameStatusChannel  chan httpClient.GameStatus // Channel for game status communication
 	gameStateChannel   chan httpClient.GameState  // Channel for game state communication
 	errChan            chan error                 // Channel for error communication
 	wg                 *sync.WaitGroup            // WaitGroup for waiting all goroutines to finish
+	safeguard          turnSafeguard              // Automatic shot fired before the turn timer runs out
+	hotseat            bool                       // Information if two players share this screen
+	bot                botPlayer                  // External bot program playing instead of the player
+	history            gameRecorder               // Record of the current game for the game history
+}
+
+// turnSafeguard represents the settings and state of the turn-timer safeguard
+type turnSafeguard struct {
+	enabled   bool              // Information if the safeguard is turned on
+	threshold int               // Number of seconds left at which the safeguard fires
+	strategy  string            // Name of the strategy choosing automatic shots
+	shooter   strategy.Strategy // Strategy of the current game, nil before the first automatic shot
+	lastShot  time.Time         // Time of the last automatic shot
+	mu        sync.Mutex        // Mutex for strategy access synchronization
+}
+
+// frontend represents an in-game user interface fed by the game loop
+type frontend interface {
+	start(ctx context.Context)                                               // Shows the interface until the player leaves or the game ends
+	displayBoard()                                                           // Shows the boards
+	handleGameState(ctx context.Context, state chan httpClient.GameState)    // Shows boards, accuracy and sunk ships
+	handleGameStatus(ctx context.Context, events chan httpClient.GameStatus) // Shows turn, timer and players
+	listenPlayerShots(ctx context.Context, shots chan string)                // Sends shots chosen by the player
+	showNotice(msg string)                                                   // Shows a message about an automatic action
+	showWarning(msg string)                                                  // Shows an error or a warning
+	showOpponentInfo(lines []string)                                         // Shows what is known about the opponent, nil hides it
+	showHeader(text string)                                                  // Shows a line at the top, like the score of a series
+	clear()                                                                  // Hides the boards before another player sits down
 }
 
 // Gui represents the game user interface
@@ -24,17 +56,28 @@ type Gui struct {
 	gui            *gui.GUI                   // User interface object
 	playerBoard    *gui.Board                 // Player's board
 	opponentBoard  *gui.Board                 // Opponent's board
+	playerMarks    *boardOverlay              // Sunk and border marks on the player's board
+	opponentMarks  *boardOverlay              // Sunk and border marks on the opponent's board
+	keyboard       *keyboardInput             // Keyboard cursor over the opponent's board
+	keyboardHelp   *gui.Text                  // Keyboard controls hint
 	playerNick     *gui.Text                  // Player's nickname
 	playerDesc     *gui.Text                  // Player's description
 	opponentNick   *gui.Text                  // Opponent's nickname
 	opponentDesc   *gui.Text                  // Opponent's description
 	turn           *gui.Text                  // Turn information
+	header         *gui.Text                  // Line at the top, like the score of a series
 	timer          *gui.Text                  // Game timer
 	waiting        *gui.Text                  // Waiting for opponent information
+	notice         *gui.Text                  // Notice about automatic actions
+	warning        *gui.Text                  // Last error or inconsistency warning
 	numberOf1Ships *gui.Text                  // Number of ships of length 1
 	numberOf2Ships *gui.Text                  // Number of ships of length 2
 	numberOf3Ships *gui.Text                  // Number of ships of length 3
 	numberOf4Ships *gui.Text                  // Number of ships of length 4
+	fleetHeader    *gui.Text                  // Header of the player's fleet panel
+	fleetShips     []*gui.Text                // Damage state of each of the player's ships
+	fleetRemaining *gui.Text                  // Number of the player's ship cells left
+	opponentInfo   []*gui.Text                // What is known about the opponent
 	gameStateChan  <-chan *appState.GameState // Channel for game state communication
 	timerChan      <-chan int                 // Channel for game time communication
 	gameStatusChan chan httpClient.GameStatus // Channel for game status communication
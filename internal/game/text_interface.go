@@ -0,0 +1,492 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/boardFormat"
+	"battleships/internal/httpClient"
+	"battleships/internal/localGame"
+	"bufio"
+	"context"
+	"fmt"
+	gui "github.com/grupawp/warships-gui/v2"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// timerAnnouncements are the seconds left at which the plain-text mode reminds about the turn timer
+var timerAnnouncements = []int{30, 10, 5}
+
+// TextUI represents the line-oriented user interface for terminals without termloop support
+type TextUI struct {
+	out      io.Writer             // Output for boards and announcements
+	input    *bufio.Reader         // Input for typed shots and commands
+	lines    chan string           // Lines read from the input
+	reading  bool                  // Information if a line is being read
+	closed   bool                  // Information if the input was closed
+	typed    chan string           // Shots typed by the player
+	state    httpClient.GameState  // Last received game state
+	status   httpClient.GameStatus // Last received game status
+	received bool                  // Information if any game state was received
+	mu       sync.Mutex            // Mutex for data access synchronization
+	printMu  sync.Mutex            // Mutex keeping printed boards in one piece
+	lastTurn *bool                 // Turn from the previous status, nil before the first one
+	warned   map[int]bool          // Timer announcements already printed in this turn
+}
+
+// NewTextUI creates a new plain-text user interface
+func NewTextUI(in io.Reader, out io.Writer) *TextUI {
+	return &TextUI{
+		out:   out,
+		input: bufio.NewReader(in),
+		lines: make(chan string),
+		typed: make(chan string),
+	}
+}
+
+// start prints the help and handles typed lines until the player leaves or the game ends
+func (t *TextUI) start(ctx context.Context) {
+	t.mu.Lock()
+	t.lastTurn = nil
+	t.warned = map[int]bool{}
+	t.received = false
+	t.state = httpClient.GameState{}
+	t.status = httpClient.GameStatus{}
+	t.mu.Unlock()
+
+	t.printf("Plain-text mode. Type a field like E7 to fire, \"board\" to show the boards, \"status\" for the turn and timer, \"quit\" to leave.\n")
+	for {
+		line, ok := t.readLine(ctx)
+		if !ok {
+			t.printf("Game ended. Press Enter to continue.\n")
+			// The line being read belongs to this screen, not to the next prompt
+			<-t.lines
+			t.reading = false
+			return
+		}
+		if !t.handleLine(ctx, strings.ToUpper(strings.TrimSpace(line))) {
+			return
+		}
+	}
+}
+
+// readLine reads a line from the input, it returns false when the context is done first
+func (t *TextUI) readLine(ctx context.Context) (string, bool) {
+	if !t.reading {
+		t.reading = true
+		go func() {
+			line, err := t.input.ReadString('\n')
+			if err != nil && line == "" {
+				// Closed input means the player can not type anything more
+				t.mu.Lock()
+				t.closed = true
+				t.mu.Unlock()
+				line = "quit"
+			}
+			t.lines <- line
+		}()
+	}
+	select {
+	case <-ctx.Done():
+		return "", false
+	case line := <-t.lines:
+		t.reading = false
+		return line, true
+	}
+}
+
+// handleLine handles a typed line, it returns false when the player leaves the game
+func (t *TextUI) handleLine(ctx context.Context, line string) bool {
+	switch line {
+	case "":
+	case "QUIT", "Q":
+		t.printf("You left the game screen.\n")
+		return false
+	case "BOARD", "B":
+		t.printBoards()
+	case "STATUS", "S":
+		t.mu.Lock()
+		status := t.status
+		t.mu.Unlock()
+		t.printf("%s, %d seconds left\n", turnText(status), status.Timer)
+	case "HELP", "H", "?":
+		t.printf("Type a field like E7 to fire, \"board\" to show the boards, \"status\" for the turn and timer, \"quit\" to leave.\n")
+		t.printf("Type \"code\" for a code of the opponent's board to share the position.\n")
+	case "CODE":
+		t.mu.Lock()
+		opp := t.state.OppBoard
+		t.mu.Unlock()
+		code, err := boardFormat.EncodeBoard(&appState.Board{PlayerState: opp})
+		if err != nil {
+			t.printf("Error encoding the board %v\n", err)
+			return true
+		}
+		t.printf("Code of the opponent's board: %s\n", code)
+	default:
+		if !isValidCoord(line) {
+			t.printf("%s is not a field of the board, use A1 to J10\n", line)
+			return true
+		}
+		select {
+		case <-ctx.Done():
+		case t.typed <- line:
+		}
+	}
+	return true
+}
+
+// displayBoard does nothing, the boards are printed with the first game state
+func (t *TextUI) displayBoard() {}
+
+// handleGameState prints the boards after every shot
+func (t *TextUI) handleGameState(ctx context.Context, states chan httpClient.GameState) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case gameState := <-states:
+			t.mu.Lock()
+			previous, first := t.state, !t.received
+			t.state = gameState
+			t.received = true
+			t.mu.Unlock()
+
+			events := describeShots(previous, gameState)
+			if first || len(events) > 0 {
+				for _, e := range events {
+					t.printf("%s\n", e)
+				}
+				t.printBoards()
+			}
+		}
+	}
+}
+
+// describeShots describes the changes between two game states
+func describeShots(previous, current httpClient.GameState) []string {
+	var events []string
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			coord := appState.FormatCoord(x, y)
+			if p, c := previous.PlayerBoard[x][y], current.PlayerBoard[x][y]; p != c && c != appState.Ship {
+				// Cells of a ship turn sunk together, the shot was the one that was not a hit before
+				if !(p == appState.Hit && c == appState.Sunk) {
+					events = append(events, fmt.Sprintf("Opponent fired at %s: %s", coord, resultText(c)))
+				}
+			}
+			if p, c := previous.OppBoard[x][y], current.OppBoard[x][y]; p != c && c != appState.Border {
+				if !(p == appState.Hit && c == appState.Sunk) {
+					events = append(events, fmt.Sprintf("You fired at %s: %s", coord, resultText(c)))
+				}
+			}
+		}
+	}
+	return events
+}
+
+// resultText describes the state of a cell after a shot
+func resultText(state string) string {
+	switch state {
+	case appState.Hit:
+		return "hit"
+	case appState.Sunk:
+		return "hit and sunk"
+	default:
+		return "miss"
+	}
+}
+
+// handleGameStatus announces turns, the timer and the end of the game
+func (t *TextUI) handleGameStatus(ctx context.Context, events chan httpClient.GameStatus) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case status := <-events:
+			t.mu.Lock()
+			previous := t.status
+			t.status = status
+			turnChanged := t.lastTurn == nil || *t.lastTurn != status.ShouldFire
+			shouldFire := status.ShouldFire
+			t.lastTurn = &shouldFire
+			if turnChanged {
+				t.warned = map[int]bool{}
+			}
+			var announce []int
+			for _, s := range timerAnnouncements {
+				if status.ShouldFire && status.Timer <= s && !t.warned[s] {
+					t.warned[s] = true
+					announce = append(announce, s)
+				}
+			}
+			t.mu.Unlock()
+
+			if status.GameStatus != previous.GameStatus {
+				t.printf("%s\n", gameStatusText(status))
+			}
+			if status.GameStatus != "game_in_progress" {
+				continue
+			}
+			if turnChanged {
+				t.printf("%s, %d seconds left\n", turnText(status), status.Timer)
+			} else if len(announce) > 0 {
+				t.printf("%d seconds left to fire!\n", status.Timer)
+			}
+		}
+	}
+}
+
+// gameStatusText describes the status of the game
+func gameStatusText(status httpClient.GameStatus) string {
+	switch status.GameStatus {
+	case "game_in_progress":
+		return fmt.Sprintf("Game in progress: %s vs %s", status.Nick, status.Opponent)
+	case "ended":
+		return fmt.Sprintf("Game ended: %s", status.LastGameStatus)
+	case "waiting", "waiting_wpbot":
+		return "Waiting for opponent..."
+	default:
+		return "Game status: " + status.GameStatus
+	}
+}
+
+// turnText describes whose turn it is
+func turnText(status httpClient.GameStatus) string {
+	if status.ShouldFire {
+		return "Your turn"
+	}
+	return "Opponent's turn"
+}
+
+// listenPlayerShots forwards typed shots to the game
+func (t *TextUI) listenPlayerShots(ctx context.Context, shots chan string) {
+	var s []string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case shot := <-t.typed:
+			if contains(s, shot) {
+				t.printf("You already fired at %s\n", shot)
+				continue
+			}
+			s = append(s, shot)
+			select {
+			case <-ctx.Done():
+				return
+			case shots <- shot:
+			}
+		}
+	}
+}
+
+// showNotice prints a short message
+func (t *TextUI) showNotice(msg string) {
+	t.printf("%s\n", msg)
+}
+
+// showWarning prints an error or a warning
+func (t *TextUI) showWarning(msg string) {
+	t.printf("WARNING: %s\n", msg)
+}
+
+// showHeader prints a line announcing the game, like the score of a series
+func (t *TextUI) showHeader(text string) {
+	if text != "" {
+		t.printf("%s\n", text)
+	}
+}
+
+// showOpponentInfo prints what is known about the opponent
+func (t *TextUI) showOpponentInfo(lines []string) {
+	for _, line := range lines {
+		t.printf("%s\n", line)
+	}
+}
+
+// clear scrolls the printed boards out of sight
+func (t *TextUI) clear() {
+	t.printf("%s", strings.Repeat("\n", 60))
+}
+
+// passKeyboard asks the next player to sit down and waits for Enter
+func (t *TextUI) passKeyboard(ctx context.Context, msg string) {
+	t.printf("%s\nPress Enter when ready.\n", msg)
+	t.readLine(ctx)
+}
+
+// printBoards prints both boards side by side with accuracy and sunk ships
+func (t *TextUI) printBoards() {
+	t.mu.Lock()
+	state, status := t.state, t.status
+	t.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\n    %-23s    %s\n", "Your board", "Opponent's board"))
+	b.WriteString("    A B C D E F G H I J        A B C D E F G H I J\n")
+	for y := 0; y < 10; y++ {
+		b.WriteString(fmt.Sprintf("%3d %s    %3d %s\n", y+1, boardRow(state.PlayerBoard, y), y+1, boardRow(state.OppBoard, y)))
+	}
+	b.WriteString("Legend: . empty, # ship, x hit, o miss, * sunk, - miss next to a sunk ship\n")
+	b.WriteString(fmt.Sprintf("Accuracy: %s %%, opponent's ships left (length 4/3/2/1): %d/%d/%d/%d\n",
+		getAccuracy(state.TotalHits, state.TotalShots),
+		state.OppShipsSunk[4], state.OppShipsSunk[3], state.OppShipsSunk[2], state.OppShipsSunk[1]))
+	if status.GameStatus == "game_in_progress" {
+		b.WriteString(fmt.Sprintf("%s, %d seconds left\n", turnText(status), status.Timer))
+	}
+	t.printf("%s", b.String())
+}
+
+// boardRow formats one row of a board with the marks of text grids
+func boardRow(states [10][10]string, y int) string {
+	row := make([]string, 10)
+	for x := 0; x < 10; x++ {
+		mark, ok := boardFormat.GridMarks[states[x][y]]
+		if !ok {
+			mark = '?'
+		}
+		row[x] = string(mark)
+	}
+	return strings.Join(row, " ")
+}
+
+// printf prints formatted text without mixing it with other printed text
+func (t *TextUI) printf(format string, a ...any) {
+	t.printMu.Lock()
+	defer t.printMu.Unlock()
+	_, _ = fmt.Fprintf(t.out, format, a...)
+}
+
+// readShips asks for the fields of every ship of the fleet, it returns false when the player gives up
+func (t *TextUI) readShips(ctx context.Context) ([]string, bool) {
+	var states [10][10]gui.State
+	var fullCoords []string
+	t.printf("Place your ships. Type the fields of a ship separated with spaces, like A1 A2 A3, or \"quit\" to stop.\n")
+	for _, length := range appState.FleetLengths {
+		for {
+			t.printf("Ship of length %d: ", length)
+			line, ok := t.readLine(ctx)
+			if !ok {
+				return nil, false
+			}
+			coords := strings.Fields(strings.ToUpper(line))
+			if len(coords) == 1 && (coords[0] == "QUIT" || coords[0] == "Q") {
+				return nil, false
+			}
+			if err := checkTypedShip(coords, length, states); err != nil {
+				t.printf("%v, try again\n", err)
+				continue
+			}
+			for _, c := range coords {
+				x, y, _ := appState.ParseCoord(c)
+				states[x][y] = gui.Ship
+			}
+			fullCoords = append(fullCoords, coords...)
+			break
+		}
+	}
+	return fullCoords, true
+}
+
+// reviewLayout shows random fleets until the player accepts one, it returns false when the player gives up
+func (t *TextUI) reviewLayout(ctx context.Context, rng *rand.Rand) ([]string, bool) {
+	for {
+		layout := localGame.RandomLayout(rng)
+		t.printf("\n%sPress Enter to use this layout, type \"again\" for another one or \"quit\" to let the server place your ships.\n",
+			formatBoard(setStates(layout)))
+
+		line, ok := t.readLine(ctx)
+		if !ok {
+			return nil, false
+		}
+		switch strings.ToUpper(strings.TrimSpace(line)) {
+		case "":
+			return layout, true
+		case "QUIT", "Q":
+			return nil, false
+		}
+	}
+}
+
+// choose prints the numbered items and reads the number or the text of one of them
+func (t *TextUI) choose(label string, items []string) (string, error) {
+	t.printf("%s\n", label)
+	for i, item := range items {
+		t.printf("  %d) %s\n", i+1, item)
+	}
+	for {
+		line, err := t.readAnswer()
+		if err != nil || len(items) == 0 {
+			return line, err
+		}
+		if n, err := strconv.Atoi(line); err == nil && n >= 1 && n <= len(items) {
+			return items[n-1], nil
+		}
+		for _, item := range items {
+			if strings.EqualFold(item, line) {
+				return item, nil
+			}
+		}
+		t.printf("Type a number from 1 to %d: ", len(items))
+	}
+}
+
+// ask reads a typed answer, validate may be nil
+func (t *TextUI) ask(label, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			t.printf("%s [%s]: ", label, def)
+		} else {
+			t.printf("%s: ", label)
+		}
+		line, err := t.readAnswer()
+		if err != nil {
+			return "", err
+		}
+		if line == "" {
+			line = def
+		}
+		if validate == nil {
+			return line, nil
+		}
+		if err := validate(line); err != nil {
+			t.printf("%v\n", err)
+			continue
+		}
+		return line, nil
+	}
+}
+
+// readAnswer reads a line typed as an answer to a question, io.EOF when the input was closed
+func (t *TextUI) readAnswer() (string, error) {
+	line, _ := t.readLine(context.Background())
+	t.mu.Lock()
+	closed := t.closed
+	t.mu.Unlock()
+	if closed {
+		return "", io.EOF
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// checkTypedShip checks if the typed fields make a valid ship of the given length
+func checkTypedShip(coords []string, length int, states [10][10]gui.State) error {
+	if len(coords) != length {
+		return fmt.Errorf("the ship needs %d field(s)", length)
+	}
+	for _, c := range coords {
+		if !isValidCoord(c) {
+			return fmt.Errorf("%s is not a field of the board", c)
+		}
+		x, y, _ := appState.ParseCoord(c)
+		if states[x][y] == gui.Ship {
+			return fmt.Errorf("%s is already taken", c)
+		}
+	}
+	if !isValidPlacement(coords) || touchesAnotherShip(coords, states) {
+		return fmt.Errorf("invalid placement")
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/httpClient"
+	"battleships/internal/strategy"
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// defaultSafeguardThreshold is the number of seconds left at which the safeguard fires by default
+const defaultSafeguardThreshold = 10
+
+// safeguardCooldown prevents firing twice before the server reports the previous shot
+const safeguardCooldown = 1500 * time.Millisecond
+
+// checkTurnTimer fires an automatic shot when our turn is about to run out
+func (a *App) checkTurnTimer(ctx context.Context, status httpClient.GameStatus) {
+	if !a.safeguard.enabled || !status.ShouldFire || status.GameStatus != "game_in_progress" {
+		return
+	}
+	if status.Timer >= a.safeguard.threshold || time.Since(a.safeguard.lastShot) < safeguardCooldown {
+		return
+	}
+
+	x, y, ok := a.safeguardShot()
+	if !ok {
+		return
+	}
+	shot := appState.FormatCoord(x, y)
+	a.safeguard.lastShot = time.Now()
+	a.ui.showNotice(fmt.Sprintf("Time almost up - safeguard fired at %s", shot))
+
+	// The shot goes through the same path as a click on the opponent's board
+	go func() {
+		select {
+		case <-ctx.Done():
+		case a.playerShotsChannel <- shot:
+		}
+	}()
+}
+
+// safeguardShot asks the safeguard strategy for a shot, the strategy is created with the first shot of a game
+func (a *App) safeguardShot() (int, int, bool) {
+	a.safeguard.mu.Lock()
+	defer a.safeguard.mu.Unlock()
+	if a.safeguard.shooter == nil {
+		s, err := strategy.New(a.safeguard.strategy, rand.New(rand.NewSource(time.Now().UnixNano())))
+		if err != nil {
+			a.ui.showWarning(fmt.Sprintf("Error: %v", err))
+			return 0, 0, false
+		}
+		a.safeguard.shooter = s
+	}
+	return a.safeguard.shooter.NextShot(a.game.GetOpponentBoard(), a.game.OpponentShipsLeft())
+}
+
+// observeShot passes the result of a shot to the safeguard strategy of the current game
+func (a *App) observeShot(shot, result string) {
+	a.safeguard.mu.Lock()
+	defer a.safeguard.mu.Unlock()
+	if a.safeguard.shooter != nil {
+		x, y, _ := appState.ParseCoord(shot)
+		a.safeguard.shooter.Observe(x, y, result)
+	}
+}
+
+// resetSafeguard forgets the strategy of the finished game
+func (a *App) resetSafeguard() {
+	a.safeguard.mu.Lock()
+	defer a.safeguard.mu.Unlock()
+	a.safeguard.shooter = nil
+}
+
+// ConfigureSafeguard lets the player turn the turn-timer safeguard on or off
+func (a *App) ConfigureSafeguard() {
+	answer, err := a.prompts.choose("Fire automatically when your turn is about to time out?", []string{"Yes", "No"})
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return
+	}
+	if answer == "No" {
+		a.safeguard.enabled = false
+		fmt.Println("Turn-timer safeguard is off")
+		return
+	}
+
+	threshold, err := a.prompts.ask("Seconds left when the safeguard should fire (1-59)", strconv.Itoa(a.safeguard.threshold), func(input string) error {
+		n, err := strconv.Atoi(input)
+		if err != nil || n < 1 || n > 59 {
+			return fmt.Errorf("enter a number between 1 and 59")
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return
+	}
+
+	name, err := a.prompts.choose("Strategy choosing the automatic shots", strategy.Names())
+	if err != nil {
+		fmt.Printf("Error executing command %v\n", err)
+		return
+	}
+
+	a.safeguard.threshold, _ = strconv.Atoi(threshold)
+	a.resetSafeguard()
+	a.safeguard.strategy = name
+	a.safeguard.enabled = true
+	fmt.Printf("Turn-timer safeguard is on, it fires with %d seconds left using the %s strategy\n", a.safeguard.threshold, name)
+}
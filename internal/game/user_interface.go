@@ -12,7 +12,7 @@ import (
 
 // NewGui creates a new user interface
 func NewGui() *Gui {
-	return &Gui{
+	g := &Gui{
 		gui:            gui.NewGUI(false),
 		playerNick:     gui.NewText(1, 27, "Player", nil),
 		playerDesc:     gui.NewText(1, 28, "Your board", nil),
@@ -20,15 +20,48 @@ func NewGui() *Gui {
 		opponentDesc:   gui.NewText(50, 28, "Opponent's board", nil),
 		playerBoard:    gui.NewBoard(1, 5, nil),
 		opponentBoard:  gui.NewBoard(50, 5, nil),
+		playerMarks:    newBoardOverlay(1, 5),
+		opponentMarks:  newBoardOverlay(50, 5),
+		keyboard:       newKeyboardInput(50, 5),
+		keyboardHelp:   gui.NewText(1, 31, "Click the opponent's board, or use arrows and Enter, or type a field like E7 and press Enter", nil),
 		waiting:        gui.NewText(10, 10, "Waiting for opponent...", nil),
+		notice:         gui.NewText(25, 1, "", nil),
+		warning:        gui.NewText(25, 2, "", &gui.TextConfig{FgColor: gui.White, BgColor: gui.Red}),
 		turn:           gui.NewText(1, 3, "", nil),
+		header:         gui.NewText(35, 0, "", nil),
 		timer:          gui.NewText(1, 1, "", nil),
 		mu:             sync.Mutex{},
 		numberOf1Ships: gui.NewText(100, 9, "4 ships of length 1", nil),
 		numberOf2Ships: gui.NewText(100, 10, "3 ships of length 2", nil),
 		numberOf3Ships: gui.NewText(100, 11, "2 ships of length 3", nil),
 		numberOf4Ships: gui.NewText(100, 12, "1 ship of length 4", nil),
+		fleetHeader:    gui.NewText(100, 14, "Your fleet:", nil),
+		fleetShips:     newTextLines(100, 15, len(appState.FleetLengths)),
+		fleetRemaining: gui.NewText(100, 26, "", nil),
+		opponentInfo:   newTextLines(100, 28, opponentInfoLines),
 	}
+	// The screen keeps every drawn copy and the keyboard handles each key once per copy, so it is drawn only here
+	g.gui.Draw(g.keyboard)
+	g.gui.Draw(g.keyboardHelp)
+	return g
+}
+
+// opponentInfoLines is the number of lines of the panel with what is known about the opponent
+const opponentInfoLines = 16
+
+// newTextLines creates n text lines one below another
+func newTextLines(x, y, n int) []*gui.Text {
+	texts := make([]*gui.Text, n)
+	for i := range texts {
+		texts[i] = gui.NewText(x, y+i, "", nil)
+	}
+	return texts
+}
+
+// start displays the GUI and blocks until the player presses ctrl+c or the game ends
+func (g *Gui) start(ctx context.Context) {
+	g.gui.Draw(gui.NewText(1, 0, "Press ctrl+c to leave the game", nil))
+	g.gui.Start(ctx, nil)
 }
 
 // SetPlayerBoard sets the player's board
@@ -48,6 +81,8 @@ func (g *Gui) sendPlayerShots(ctx context.Context, shotsChannel chan string) {
 func (g *Gui) displayBoard() {
 	g.gui.Draw(g.playerBoard)
 	g.gui.Draw(g.opponentBoard)
+	g.playerMarks.draw(g.gui)
+	g.opponentMarks.draw(g.gui)
 }
 
 // handleGameStatus handles the game status
@@ -79,6 +114,56 @@ loop:
 	}
 }
 
+// showNotice shows a short message above the boards
+func (g *Gui) showNotice(msg string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.notice.SetText(msg)
+	g.gui.Draw(g.notice)
+}
+
+// showWarning shows an error or a warning above the boards
+func (g *Gui) showWarning(msg string) {
+	g.gui.Log("%s", msg)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.warning.SetText(msg)
+	g.gui.Draw(g.warning)
+}
+
+// showHeader shows a line next to the hint at the top of the screen
+func (g *Gui) showHeader(text string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.header.SetText(text)
+	g.gui.Draw(g.header)
+}
+
+// showOpponentInfo shows what is known about the opponent below the fleet panel, extra lines are cut off
+func (g *Gui) showOpponentInfo(lines []string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, text := range g.opponentInfo {
+		line := ""
+		if i < len(lines) {
+			line = lines[i]
+		}
+		text.SetText(line)
+		g.gui.Draw(text)
+	}
+}
+
+// clear hides both boards
+func (g *Gui) clear() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var empty [10][10]gui.State
+	g.playerBoard.SetStates(empty)
+	g.opponentBoard.SetStates(empty)
+	g.playerMarks.update([10][10]string{})
+	g.opponentMarks.update([10][10]string{})
+}
+
 // updatePlayers updates players
 func (g *Gui) updatePlayers(status httpClient.GameStatus) {
 	g.playerNick.SetText(status.Nick)
@@ -115,7 +200,8 @@ loop:
 			g.mu.Lock()
 			g.playerBoard.SetStates(mapStatesToGuiMarks(gameState.PlayerBoard))
 			g.opponentBoard.SetStates(mapStatesToGuiMarks(gameState.OppBoard))
-			g.opponentBoard.SetStates(mapStatesToGuiMarks(gameState.OppBoard))
+			g.playerMarks.update(gameState.PlayerBoard)
+			g.opponentMarks.update(gameState.OppBoard)
 			g.gui.Draw(gui.NewText(1, 28, gameState.PlayerDesc, nil))
 			g.gui.Draw(gui.NewText(58, 28, gameState.OppDesc, nil))
 			g.gui.Draw(gui.NewText(1, 2, fmt.Sprintf("Accuracy: %s %%",
@@ -127,10 +213,44 @@ loop:
 			g.numberOf3Ships.SetText(strconv.Itoa(gameState.OppShipsSunk[3]) + " ships of length 3")
 			g.numberOf4Ships.SetText(strconv.Itoa(gameState.OppShipsSunk[4]) + " ships of length 4")
 			g.drawLegend()
+			g.updateFleet(gameState.PlayerFleet)
 		}
 	}
 }
 
+// updateFleet updates the panel with the damage state of the player's ships
+func (g *Gui) updateFleet(fleet []appState.ShipStatus) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	remaining := 0
+	for i, text := range g.fleetShips {
+		if i >= len(fleet) {
+			text.SetText("")
+			g.gui.Draw(text)
+			continue
+		}
+		ship := fleet[i]
+		remaining += ship.Length - ship.Hits
+		text.SetText(fmt.Sprintf("Ship of length %d: %s", ship.Length, shipDamage(ship)))
+		g.gui.Draw(text)
+	}
+	g.fleetRemaining.SetText(fmt.Sprintf("Cells remaining: %d", remaining))
+	g.gui.Draw(g.fleetHeader)
+	g.gui.Draw(g.fleetRemaining)
+}
+
+// shipDamage describes the damage state of a ship
+func shipDamage(ship appState.ShipStatus) string {
+	switch {
+	case ship.IsSunk():
+		return "sunk"
+	case ship.Hits > 0:
+		return fmt.Sprintf("hit %d/%d", ship.Hits, ship.Length)
+	default:
+		return "intact"
+	}
+}
+
 // getAccuracy calculates accuracy
 func getAccuracy(hits, shots int) string {
 	if shots == 0 {
@@ -152,10 +272,11 @@ loop:
 
 			break loop
 		default:
-			shot := g.opponentBoard.Listen(ctx)
-			if shot != "" && !contains(s, shot) {
-				s = append(s, shot)
-				shots <- shot
+			for _, shot := range listenBoard(ctx, g.opponentBoard, g.keyboard) {
+				if !contains(s, shot) {
+					s = append(s, shot)
+					shots <- shot
+				}
 			}
 		}
 	}
@@ -167,6 +288,8 @@ func (g *Gui) drawLegend() {
 	g.gui.Draw(gui.NewText(100, 5, "M - Miss", nil))
 	g.gui.Draw(gui.NewText(100, 6, "S - Ship", nil))
 	g.gui.Draw(gui.NewText(100, 7, "~ - Empty", nil))
+	g.gui.Draw(gui.NewText(120, 4, "X - Sunk", nil))
+	g.gui.Draw(gui.NewText(120, 5, ". - Miss next to a sunk ship", nil))
 }
 
 // mapStatesToGuiMarks maps states to GUI marks
@@ -174,8 +297,12 @@ func mapStatesToGuiMarks(sts [10][10]string) [10][10]gui.State {
 	var mapped [10][10]gui.State
 	for i, row := range sts {
 		for j, s := range row {
-			if s == appState.Sunk {
+			// Sunk ships and their borders get their own marks from the board overlay
+			switch s {
+			case appState.Sunk:
 				s = appState.Hit
+			case appState.Border:
+				s = appState.Miss
 			}
 			mapped[i][j] = gui.State(s)
 		}
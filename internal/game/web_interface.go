@@ -0,0 +1,302 @@
+package game
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/httpClient"
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+//go:embed web/index.html
+var webPage []byte
+
+// webWriteTimeout limits the time spent sending an update to a single browser
+const webWriteTimeout = 2 * time.Second
+
+// webMessage represents a message exchanged with the browser
+type webMessage struct {
+	Type         string                `json:"type"`                     // state, status, opponent, header, clear, notice, warning, fire or leave
+	PlayerBoard  *[10][10]string       `json:"player_board,omitempty"`   // Player's board, indexed by column and row
+	OppBoard     *[10][10]string       `json:"opp_board,omitempty"`      // Opponent's board, indexed by column and row
+	Accuracy     string                `json:"accuracy,omitempty"`       // Accuracy of the player's shots in percent
+	PlayerDesc   string                `json:"player_desc,omitempty"`    // Player's description
+	OppDesc      string                `json:"opp_desc,omitempty"`       // Opponent's description
+	OppShipsLeft map[int]int           `json:"opp_ships_left,omitempty"` // Opponent's ships left by length
+	Fleet        []appState.ShipStatus `json:"fleet,omitempty"`          // Damage state of the player's ships
+	Status       string                `json:"status,omitempty"`         // Game status from the server
+	Nick         string                `json:"nick,omitempty"`           // Player's nickname
+	Opponent     string                `json:"opponent,omitempty"`       // Opponent's nickname
+	ShouldFire   bool                  `json:"should_fire,omitempty"`    // Information if it is the player's turn
+	Timer        int                   `json:"timer,omitempty"`          // Seconds left in the turn
+	Text         string                `json:"text,omitempty"`           // Notice, warning or header text
+	Lines        []string              `json:"lines,omitempty"`          // What is known about the opponent
+	Coord        string                `json:"coord,omitempty"`          // Field clicked in the browser
+}
+
+// WebUI represents the in-game user interface served to a browser over HTTP and WebSocket
+type WebUI struct {
+	addr     string                   // Address the server listens on
+	upgrader websocket.Upgrader       // Upgrades HTTP connections to WebSocket
+	clients  map[*websocket.Conn]bool // Connected browsers
+	state    *webMessage              // Last game state sent to browsers
+	status   *webMessage              // Last game status sent to browsers
+	opponent *webMessage              // Last information about the opponent sent to browsers
+	header   *webMessage              // Last header sent to browsers
+	shots    chan string              // Fields clicked in the browser
+	leave    chan struct{}            // Signals that the player left the game in the browser
+	console  *TextUI                  // Terminal used for placing ships, the browser shows only the game
+	mu       sync.Mutex               // Mutex for data access synchronization
+}
+
+// NewWebUI starts a local HTTP server with the game screen and returns its user interface
+func NewWebUI(addr string) (*WebUI, error) {
+	w := &WebUI{
+		clients: make(map[*websocket.Conn]bool),
+		shots:   make(chan string),
+		leave:   make(chan struct{}, 1),
+		console: NewTextUI(os.Stdin, os.Stdout),
+		upgrader: websocket.Upgrader{
+			// Only pages served by this server may connect
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || origin == "http://"+r.Host
+			},
+		},
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error starting the web server: %w", err)
+	}
+	w.addr = listener.Addr().String()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", w.servePage)
+	mux.HandleFunc("/ws", w.serveWebSocket)
+	go func() {
+		_ = http.Serve(listener, mux)
+	}()
+	return w, nil
+}
+
+// URL returns the address of the game screen
+func (w *WebUI) URL() string {
+	return "http://" + w.addr
+}
+
+// servePage serves the game screen
+func (w *WebUI) servePage(rw http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(rw, r)
+		return
+	}
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = rw.Write(webPage)
+}
+
+// serveWebSocket sends updates to a browser and reads its clicks
+func (w *WebUI) serveWebSocket(rw http.ResponseWriter, r *http.Request) {
+	conn, err := w.upgrader.Upgrade(rw, r, nil)
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	w.clients[conn] = true
+	for _, m := range []*webMessage{w.state, w.status, w.opponent, w.header} {
+		if m != nil {
+			w.send(conn, m)
+		}
+	}
+	w.mu.Unlock()
+
+	defer func() {
+		w.mu.Lock()
+		delete(w.clients, conn)
+		w.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	for {
+		var m webMessage
+		if err := conn.ReadJSON(&m); err != nil {
+			return
+		}
+		switch m.Type {
+		case "fire":
+			if isValidCoord(m.Coord) {
+				select {
+				case w.shots <- m.Coord:
+				default:
+					// Nobody waits for shots outside of a game
+				}
+			}
+		case "leave":
+			select {
+			case w.leave <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// broadcast sends a message to every connected browser
+func (w *WebUI) broadcast(m *webMessage) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for conn := range w.clients {
+		w.send(conn, m)
+	}
+}
+
+// send sends a message to a browser, the caller has to hold the mutex
+func (w *WebUI) send(conn *websocket.Conn, m *webMessage) {
+	_ = conn.SetWriteDeadline(time.Now().Add(webWriteTimeout))
+	if err := conn.WriteJSON(m); err != nil {
+		_ = conn.Close()
+		delete(w.clients, conn)
+	}
+}
+
+// start waits until the game ends or the player leaves it in the browser
+func (w *WebUI) start(ctx context.Context) {
+	// Neither the screen nor a leave request from a previous game counts
+	select {
+	case <-w.leave:
+	default:
+	}
+	w.mu.Lock()
+	w.state, w.status = nil, nil
+	w.mu.Unlock()
+	fmt.Printf("The game screen is available at %s\n", w.URL())
+	select {
+	case <-ctx.Done():
+	case <-w.leave:
+	}
+}
+
+// readShips asks for the fields of every ship in the terminal
+func (w *WebUI) readShips(ctx context.Context) ([]string, bool) {
+	return w.console.readShips(ctx)
+}
+
+// reviewLayout shows random fleets in the terminal until the player accepts one
+func (w *WebUI) reviewLayout(ctx context.Context, rng *rand.Rand) ([]string, bool) {
+	return w.console.reviewLayout(ctx, rng)
+}
+
+// displayBoard does nothing, the browser draws the boards with the first game state
+func (w *WebUI) displayBoard() {}
+
+// handleGameState sends boards, accuracy and sunk ships to the browser
+func (w *WebUI) handleGameState(ctx context.Context, states chan httpClient.GameState) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case gameState := <-states:
+			m := &webMessage{
+				Type:         "state",
+				PlayerBoard:  &gameState.PlayerBoard,
+				OppBoard:     &gameState.OppBoard,
+				Accuracy:     getAccuracy(gameState.TotalHits, gameState.TotalShots),
+				PlayerDesc:   gameState.PlayerDesc,
+				OppDesc:      gameState.OppDesc,
+				OppShipsLeft: gameState.OppShipsSunk,
+				Fleet:        gameState.PlayerFleet,
+			}
+			w.mu.Lock()
+			w.state = m
+			w.mu.Unlock()
+			w.broadcast(m)
+		}
+	}
+}
+
+// handleGameStatus sends the turn, the timer and the players to the browser
+func (w *WebUI) handleGameStatus(ctx context.Context, events chan httpClient.GameStatus) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case status := <-events:
+			m := &webMessage{
+				Type:       "status",
+				Status:     status.GameStatus,
+				Nick:       status.Nick,
+				Opponent:   status.Opponent,
+				ShouldFire: status.ShouldFire,
+				Timer:      status.Timer,
+			}
+			w.mu.Lock()
+			w.status = m
+			w.mu.Unlock()
+			w.broadcast(m)
+		}
+	}
+}
+
+// listenPlayerShots forwards fields clicked in the browser to the game
+func (w *WebUI) listenPlayerShots(ctx context.Context, shots chan string) {
+	var s []string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case shot := <-w.shots:
+			if contains(s, shot) {
+				continue
+			}
+			s = append(s, shot)
+			select {
+			case <-ctx.Done():
+				return
+			case shots <- shot:
+			}
+		}
+	}
+}
+
+// clear hides the boards in the browser
+func (w *WebUI) clear() {
+	w.mu.Lock()
+	w.state, w.status = nil, nil
+	w.mu.Unlock()
+	w.broadcast(&webMessage{Type: "clear"})
+}
+
+// showNotice sends a message about an automatic action to the browser
+func (w *WebUI) showNotice(msg string) {
+	w.broadcast(&webMessage{Type: "notice", Text: msg})
+}
+
+// showHeader sends a line for the top of the page to the browser
+func (w *WebUI) showHeader(text string) {
+	m := &webMessage{Type: "header", Text: text}
+	w.mu.Lock()
+	w.header = m
+	w.mu.Unlock()
+	w.broadcast(m)
+}
+
+// showOpponentInfo sends what is known about the opponent to the browser
+func (w *WebUI) showOpponentInfo(lines []string) {
+	m := &webMessage{Type: "opponent", Lines: lines}
+	w.mu.Lock()
+	w.opponent = m
+	w.mu.Unlock()
+	w.broadcast(m)
+}
+
+// showWarning sends an error or a warning to the browser
+func (w *WebUI) showWarning(msg string) {
+	w.broadcast(&webMessage{Type: "warning", Text: msg})
+}
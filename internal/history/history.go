@@ -0,0 +1,211 @@
+// Package history keeps the finished games of the player in a JSON file in the user's config directory.
+//
+// Every game remembers the opponent, the fields of their ships the player found and the order of
+// their shots, so the games against the same opponent can be turned into a Profile of their habits.
+// Games of a best-of-N series are kept together with the series they belong to.
+package history
+
+import (
+	"battleships/internal/appState"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// historyFile is the name of the file with the finished games
+const historyFile = "battleships-history.json"
+
+// Results of a game from the player's point of view, the same as the last game status of the server
+const (
+	Win  = "win"
+	Lose = "lose"
+)
+
+// Game represents a finished game
+type Game struct {
+	Started  time.Time `json:"started"`   // Time the game started
+	Ended    time.Time `json:"ended"`     // Time the game ended
+	Nick     string    `json:"nick"`      // Player's nickname
+	Opponent string    `json:"opponent"`  // Opponent's nickname
+	Result   string    `json:"result"`    // Win or Lose
+	Fleet    []string  `json:"fleet"`     // Fields of the player's ships
+	Shots    []Shot    `json:"shots"`     // Player's shots in the order they were fired
+	OppShots []string  `json:"opp_shots"` // Opponent's shots in the order they were fired
+	OppShips []string  `json:"opp_ships"` // Fields of the opponent's ships the player found
+}
+
+// Shot represents a shot of the player and its result: miss, hit or sunk
+type Shot struct {
+	Coord  string `json:"coord"`
+	Result string `json:"result"`
+}
+
+// Boards returns the player's and the opponent's boards as they were at the end of the game
+func (g Game) Boards() (*appState.Board, *appState.Board) {
+	state := appState.InitializeNewGameState()
+	for _, c := range g.Fleet {
+		if x, y, err := appState.ParseCoord(c); err == nil {
+			state.AddShip(x, y)
+		}
+	}
+	for _, c := range g.OppShots {
+		if x, y, err := appState.ParseCoord(c); err == nil {
+			state.MarkPlayerBoard(x, y)
+		}
+	}
+	marks := map[string]string{appState.ResultMiss: appState.Miss, appState.ResultHit: appState.Hit, appState.ResultSunk: appState.Sunk}
+	for _, shot := range g.Shots {
+		if x, y, err := appState.ParseCoord(shot.Coord); err == nil {
+			// Results that do not fit the rules were reported during the game, the board shows them as they came
+			_, _ = state.MarkOpponentBoard(x, y, marks[shot.Result])
+		}
+	}
+	return &appState.Board{PlayerState: state.GetPlayerBoard()}, &appState.Board{PlayerState: state.GetOpponentBoard()}
+}
+
+// Store represents the file with the finished games
+type Store struct {
+	path string     // Path of the file
+	mu   sync.Mutex // Mutex for file access synchronization
+}
+
+// DefaultPath returns the path of the history file in the user's config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding the config directory: %w", err)
+	}
+	return filepath.Join(dir, "battleships", historyFile), nil
+}
+
+// Open returns the store kept in the given file, the file is created with the first game
+func Open(path string) *Store {
+	return &Store{path: path}
+}
+
+// file represents the content of the history file
+type file struct {
+	Games  []Game   `json:"games"`  // Games played outside of a series
+	Series []Series `json:"series"` // Series with their games
+}
+
+// Games returns all finished games including the games of series, the oldest first
+func (s *Store) Games() ([]Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	games := f.Games
+	for _, series := range f.Series {
+		games = append(games, series.Games...)
+	}
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].Started.Before(games[j].Started)
+	})
+	return games, nil
+}
+
+// Opponent returns the finished games against the opponent, the oldest first
+func (s *Store) Opponent(nick string) ([]Game, error) {
+	games, err := s.Games()
+	if err != nil {
+		return nil, err
+	}
+	var found []Game
+	for _, g := range games {
+		if g.Opponent == nick {
+			found = append(found, g)
+		}
+	}
+	return found, nil
+}
+
+// Add appends a finished game played outside of a series to the file
+func (s *Store) Add(g Game) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := s.load()
+	if err != nil {
+		return err
+	}
+	f.Games = append(f.Games, g)
+	return s.save(f)
+}
+
+// SaveSeries saves the series with all its games, a series started at the same time is replaced
+func (s *Store) SaveSeries(series Series) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := s.load()
+	if err != nil {
+		return err
+	}
+	for i := range f.Series {
+		if f.Series[i].Started.Equal(series.Started) {
+			f.Series[i] = series
+			return s.save(f)
+		}
+	}
+	f.Series = append(f.Series, series)
+	return s.save(f)
+}
+
+// load reads the file, a missing file holds no games, the caller has to hold the mutex
+func (s *Store) load() (file, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file{}, nil
+	}
+	if err != nil {
+		return file{}, fmt.Errorf("error reading the game history: %w", err)
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		// Files written before series were kept hold only the list of games
+		var games []Game
+		if json.Unmarshal(data, &games) != nil {
+			return file{}, fmt.Errorf("error reading the game history: %w", err)
+		}
+		return file{Games: games}, nil
+	}
+	return f, nil
+}
+
+// save replaces the file, the caller has to hold the mutex
+func (s *Store) save(f file) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error saving the game history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("error saving the game history: %w", err)
+	}
+	// A game interrupted while writing must not destroy the earlier ones
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("error saving the game history: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("error saving the game history: %w", err)
+	}
+	return nil
+}
+
+// SaveReplay writes a single game with all shots in order to a file
+func SaveReplay(path string, g Game) error {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error saving the replay: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error saving the replay: %w", err)
+	}
+	return nil
+}
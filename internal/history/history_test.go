@@ -0,0 +1,54 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadLegacyGameList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), historyFile)
+	legacy := `[{"started":"2024-05-01T10:00:00Z","opponent":"bot","result":"win","fleet":["A1"]},
+		{"started":"2024-05-02T10:00:00Z","opponent":"bot","result":"lose"}]`
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := Open(path)
+	games, err := store.Games()
+	if err != nil {
+		t.Fatalf("Games() of a legacy file: %v", err)
+	}
+	if len(games) != 2 || games[0].Result != Win || games[1].Result != Lose {
+		t.Fatalf("Games() of a legacy file = %+v, want the two games", games)
+	}
+
+	// The next save moves the file to the current format and keeps the old games
+	series, err := NewSeries("player", "bot", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := series.Add(Game{Opponent: "bot", Result: Win}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveSeries(*series); err != nil {
+		t.Fatalf("SaveSeries: %v", err)
+	}
+	games, err = store.Games()
+	if err != nil {
+		t.Fatalf("Games() after saving: %v", err)
+	}
+	if len(games) != 3 {
+		t.Errorf("Games() after saving a series returned %d games, want 3", len(games))
+	}
+}
+
+func TestLoadDamagedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), historyFile)
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path).Games(); err == nil {
+		t.Error("Games() of a damaged file returned no error")
+	}
+}
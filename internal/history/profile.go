@@ -0,0 +1,115 @@
+package history
+
+import (
+	"battleships/internal/appState"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// openingLength is the number of the first shots of a game counted as its opening
+const openingLength = 3
+
+// Profile represents the habits of an opponent seen in the games against them
+type Profile struct {
+	Opponent   string         // Opponent's nickname
+	Games      int            // Number of games against the opponent
+	Wins       int            // Number of games the player won
+	Losses     int            // Number of games the player lost
+	ShipFields [10][10]int    // Number of games in which a field held a ship the player found, indexed by column and row
+	Openings   map[string]int // Number of games the opponent opened with a shot at a field
+}
+
+// NewProfile builds the profile of an opponent from the games against them, other games are skipped
+func NewProfile(opponent string, games []Game) Profile {
+	p := Profile{Opponent: opponent, Openings: make(map[string]int)}
+	for _, g := range games {
+		if g.Opponent != opponent {
+			continue
+		}
+		p.Games++
+		switch g.Result {
+		case Win:
+			p.Wins++
+		case Lose:
+			p.Losses++
+		}
+		for _, c := range g.OppShips {
+			if x, y, err := appState.ParseCoord(c); err == nil {
+				p.ShipFields[x][y]++
+			}
+		}
+		for _, c := range g.OppShots[:min(openingLength, len(g.OppShots))] {
+			p.Openings[c]++
+		}
+	}
+	return p
+}
+
+// TopOpenings returns at most n fields the opponent opens with most often, ties in board order
+func (p Profile) TopOpenings(n int) []string {
+	fields := make([]string, 0, len(p.Openings))
+	for c := range p.Openings {
+		fields = append(fields, c)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if p.Openings[fields[i]] != p.Openings[fields[j]] {
+			return p.Openings[fields[i]] > p.Openings[fields[j]]
+		}
+		return fieldOrder(fields[i]) < fieldOrder(fields[j])
+	})
+	return fields[:min(n, len(fields))]
+}
+
+// fieldOrder orders fields row by row
+func fieldOrder(coord string) int {
+	x, y, _ := appState.ParseCoord(coord)
+	return y*10 + x
+}
+
+// HeadToHead describes the results of the games against the opponent
+func (p Profile) HeadToHead() string {
+	if p.Games == 0 {
+		return fmt.Sprintf("Head-to-head with %s: no games yet", p.Opponent)
+	}
+	return fmt.Sprintf("Head-to-head with %s: won %d, lost %d of %d", p.Opponent, p.Wins, p.Losses, p.Games)
+}
+
+// Lines describes the profile in short lines for a side panel, the heatmap shows ship fields from 1 to 9
+func (p Profile) Lines() []string {
+	lines := []string{p.HeadToHead()}
+
+	most := 0
+	for x := range p.ShipFields {
+		for y := range p.ShipFields[x] {
+			most = max(most, p.ShipFields[x][y])
+		}
+	}
+	if most > 0 {
+		lines = append(lines, "Where their ships were:", "   ABCDEFGHIJ")
+		for y := 0; y < 10; y++ {
+			var row strings.Builder
+			fmt.Fprintf(&row, "%2d ", y+1)
+			for x := 0; x < 10; x++ {
+				row.WriteByte(heatMark(p.ShipFields[x][y], most))
+			}
+			lines = append(lines, row.String())
+		}
+	}
+
+	if openings := p.TopOpenings(5); len(openings) > 0 {
+		for i, c := range openings {
+			openings[i] = fmt.Sprintf("%s (%d)", c, p.Openings[c])
+		}
+		lines = append(lines, "Their first shots: "+strings.Join(openings, ", "))
+	}
+	return lines
+}
+
+// heatMark shows how often a field held a ship compared with the most frequent field
+func heatMark(count, most int) byte {
+	if count == 0 {
+		return '.'
+	}
+	return byte('0' + (count*9+most-1)/most)
+}
@@ -0,0 +1,83 @@
+package history
+
+import (
+	"fmt"
+	"time"
+)
+
+// SeriesLengths are the numbers of games a series can be played over
+var SeriesLengths = []int{3, 5, 7}
+
+// Series represents a best-of-N series against a single opponent
+type Series struct {
+	Started  time.Time `json:"started"`  // Time the series started
+	Ended    time.Time `json:"ended"`    // Time the series ended, zero while it is played
+	Nick     string    `json:"nick"`     // Player's nickname
+	Opponent string    `json:"opponent"` // Opponent's nickname, empty until the first game starts
+	Bot      bool      `json:"bot"`      // Information if the series is played against the server bot, whatever its nickname
+	BestOf   int       `json:"best_of"`  // Number of games the series is played over
+	Wins     int       `json:"wins"`     // Number of games the player won
+	Losses   int       `json:"losses"`   // Number of games the player lost
+	Games    []Game    `json:"games"`    // Games of the series in the order they were played
+}
+
+// NewSeries creates a series over the given number of games, the opponent may be empty when not known yet
+func NewSeries(nick, opponent string, bestOf int) (*Series, error) {
+	if bestOf < 1 || bestOf%2 == 0 {
+		return nil, fmt.Errorf("a series has to be played over an odd number of games, not %d", bestOf)
+	}
+	return &Series{Started: time.Now(), Nick: nick, Opponent: opponent, BestOf: bestOf}, nil
+}
+
+// Counts reports whether a game belongs to the series, the first game decides the opponent of a series without one
+func (s *Series) Counts(g Game) bool {
+	return !s.Over() && (s.Bot || s.Opponent == "" || s.Opponent == g.Opponent)
+}
+
+// Add adds a game to the series, the result of the game decides the score.
+// A game without a win or a loss would not move the score, so it is not added.
+func (s *Series) Add(g Game) error {
+	switch g.Result {
+	case Win:
+		s.Wins++
+	case Lose:
+		s.Losses++
+	default:
+		return fmt.Errorf("the game against %s ended without a result (%q), it does not count in the series", g.Opponent, g.Result)
+	}
+	if s.Opponent == "" {
+		s.Opponent = g.Opponent
+	}
+	s.Games = append(s.Games, g)
+	if s.Over() {
+		s.Ended = g.Ended
+	}
+	return nil
+}
+
+// Over reports whether one of the players won enough games to win the series
+func (s *Series) Over() bool {
+	needed := s.BestOf/2 + 1
+	return s.Wins >= needed || s.Losses >= needed
+}
+
+// Result returns Win or Lose once the series is over and an empty string before
+func (s *Series) Result() string {
+	switch {
+	case !s.Over():
+		return ""
+	case s.Wins > s.Losses:
+		return Win
+	default:
+		return Lose
+	}
+}
+
+// Score describes the series and its score from the player's point of view
+func (s *Series) Score() string {
+	opponent := s.Opponent
+	if opponent == "" {
+		opponent = "the next opponent"
+	}
+	return fmt.Sprintf("Best of %d against %s: %d-%d", s.BestOf, opponent, s.Wins, s.Losses)
+}
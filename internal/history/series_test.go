@@ -0,0 +1,100 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// playSeries adds games with the results to a new series and returns it
+func playSeries(t *testing.T, bestOf int, results string) *Series {
+	t.Helper()
+	s, err := NewSeries("player", "", bestOf)
+	if err != nil {
+		t.Fatalf("NewSeries(%d): %v", bestOf, err)
+	}
+	for i, r := range results {
+		result := Lose
+		if r == 'W' {
+			result = Win
+		}
+		if err := s.Add(Game{Opponent: "opponent", Result: result, Ended: time.Unix(int64(i+1), 0)}); err != nil {
+			t.Fatalf("Add game %d: %v", i+1, err)
+		}
+	}
+	return s
+}
+
+func TestSeriesOver(t *testing.T) {
+	tests := []struct {
+		bestOf  int
+		results string
+		over    bool
+		result  string
+	}{
+		{3, "", false, ""},
+		{3, "W", false, ""},
+		{3, "WL", false, ""},
+		{3, "WW", true, Win},
+		{3, "LWL", true, Lose},
+		{5, "WWLL", false, ""},
+		{5, "WWW", true, Win},
+		{5, "LLWWL", true, Lose},
+		{7, "WLWLWL", false, ""},
+		{7, "LLL", false, ""},
+		{7, "WLWLWLW", true, Win},
+		{7, "LLLL", true, Lose},
+	}
+	for _, tt := range tests {
+		s := playSeries(t, tt.bestOf, tt.results)
+		if s.Over() != tt.over {
+			t.Errorf("best of %d after %q: Over() = %v, want %v", tt.bestOf, tt.results, s.Over(), tt.over)
+		}
+		if s.Result() != tt.result {
+			t.Errorf("best of %d after %q: Result() = %q, want %q", tt.bestOf, tt.results, s.Result(), tt.result)
+		}
+		// The series ends with its deciding game
+		if ended := !s.Ended.IsZero(); ended != tt.over {
+			t.Errorf("best of %d after %q: Ended = %v, want it set only when over", tt.bestOf, tt.results, s.Ended)
+		}
+	}
+}
+
+func TestSeriesAddGameWithoutResult(t *testing.T) {
+	s := playSeries(t, 3, "W")
+	if err := s.Add(Game{Opponent: "opponent", Result: ""}); err == nil {
+		t.Fatal("Add of a game without a result returned no error")
+	}
+	if s.Wins != 1 || s.Losses != 0 || len(s.Games) != 1 {
+		t.Errorf("a game without a result changed the series to %d-%d with %d games", s.Wins, s.Losses, len(s.Games))
+	}
+}
+
+func TestSeriesCounts(t *testing.T) {
+	s := playSeries(t, 3, "W")
+	if s.Opponent != "opponent" {
+		t.Errorf("the first game set the opponent to %q, want %q", s.Opponent, "opponent")
+	}
+	if s.Counts(Game{Opponent: "someone else"}) {
+		t.Error("a game against another opponent counts in the series")
+	}
+	s = playSeries(t, 3, "WW")
+	if s.Counts(Game{Opponent: "opponent"}) {
+		t.Error("a game counts in a series that is over")
+	}
+}
+
+func TestNewSeriesRejectsEvenLengths(t *testing.T) {
+	for _, bestOf := range []int{0, 2, 4, -3} {
+		if _, err := NewSeries("player", "", bestOf); err == nil {
+			t.Errorf("NewSeries(%d) returned no error", bestOf)
+		}
+	}
+}
+
+func TestSeriesScore(t *testing.T) {
+	s := playSeries(t, 5, "WL")
+	if score := s.Score(); !strings.Contains(score, "Best of 5") || !strings.Contains(score, "1-1") {
+		t.Errorf("Score() = %q, want the length and the score of the series", score)
+	}
+}
@@ -2,6 +2,8 @@ package httpClient
 
 import (
 	"battleships/internal/appState"
+	"battleships/internal/auditor"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -14,51 +16,50 @@ func setStatesFromCoords(coords []string, s string) [10][10]string {
 		state[i] = [10]string{}
 	}
 	for _, coord := range coords {
-		x, y := mapToState(coord)
+		x, y, _ := appState.ParseCoord(coord)
 		state[x][y] = s
 	}
 	return state
 }
 
-// mapToState converts string to int
-func mapToState(coord string) (int, int) {
-	if len(coord) > 2 {
-		return int(coord[0] - 65), 9
-	}
-	x := int(coord[0] - 65)
-	y := int(coord[1] - 49)
-	return x, y
-}
-
 // NewGame returns a new game instance
 func NewGame() *Game {
+	client := NewClient("https://go-pjatk-server.fly.dev/api", "")
 	return &Game{
-		Client: NewClient("https://go-pjatk-server.fly.dev/api", ""),
-		state:  appState.InitializeNewGameState(),
+		Client:  client,
+		server:  client,
+		state:   appState.InitializeNewGameState(),
+		auditor: auditor.NewAuditor(),
 	}
 }
 
+// NewLocalGame returns a new game instance playing against the given server instead of the remote API
+func NewLocalGame(server Server) *Game {
+	g := NewGame()
+	g.server = server
+	return g
+}
+
 // FireShot fires a shot at the given coordinate
 func (g *Game) FireShot(coord string) (FireResult, int, error) {
-	result, err := g.Client.Fire(FireData{Coord: coord})
+	result, err := g.server.Fire(FireData{Coord: coord})
 	if err != nil {
 		return FireResult{}, 0, err
 	}
-	l := g.MarkOpponent(coord, result)
+	l, err := g.MarkOpponent(coord, result)
 	return result, l, err
 }
 
 // StartGame starts the game
-func (g *Game) StartGame(nick, desc, targetNick string, coords []string, botGame bool) {
-	_, err := g.Client.StartGame(nick, desc, targetNick, coords, botGame)
-	if err != nil {
-		return
-	}
+func (g *Game) StartGame(nick, desc, targetNick string, coords []string, botGame bool) error {
+	g.auditor.Reset()
+	_, err := g.server.StartGame(nick, desc, targetNick, coords, botGame)
+	return err
 }
 
 // GetGameStatus returns the current game status
 func (g *Game) GetGameStatus() (GameStatus, error) {
-	gameState, err := g.Client.GetGameStatus()
+	gameState, err := g.server.GetGameStatus()
 	if err != nil {
 		return GameStatus{}, err
 	}
@@ -76,12 +77,12 @@ func (g *Game) SetPlayerBoard(coords []string) ([10][10]string, error) {
 
 // GetDescription returns the game description
 func (g *Game) GetDescription() (GameDescription, error) {
-	return g.Client.GetGameDescription()
+	return g.server.GetGameDescription()
 }
 
 // LoadPlayerBoard loads the player's board
 func (g *Game) LoadPlayerBoard() (*GameBoard, error) {
-	return g.Client.GetGameBoard()
+	return g.server.GetGameBoard()
 }
 
 // UpdateGameState updates the game state
@@ -97,7 +98,7 @@ func (g *Game) GetPlayerBoard() [10][10]string {
 // MarkOpponentShots marks the opponent's shots on the player's board
 func (g *Game) MarkOpponentShots(shots []string) {
 	for _, coord := range shots {
-		x, y := mapToState(coord)
+		x, y, _ := appState.ParseCoord(coord)
 		g.state.MarkPlayerBoard(x, y)
 	}
 }
@@ -113,11 +114,11 @@ func (g *Game) GetOpponentBoard() [10][10]string {
 }
 
 // MarkOpponent marks the shot result on the opponent's board
-func (g *Game) MarkOpponent(shot string, result FireResult) int {
+func (g *Game) MarkOpponent(shot string, result FireResult) (int, error) {
 	if shot == "" {
-		return 0
+		return 0, nil
 	}
-	x, y := mapToState(shot)
+	x, y, _ := appState.ParseCoord(shot)
 	var mark string
 	switch result.Result {
 	case "sunk":
@@ -128,9 +129,20 @@ func (g *Game) MarkOpponent(shot string, result FireResult) int {
 		mark = appState.Miss
 	}
 	g.state.IncrementHitCount(result.Result)
+	g.auditor.Record(x, y, result.Result)
 	return g.state.MarkOpponentBoard(x, y, mark)
 }
 
+// AuditShots checks if the results of all shots fired in this game fit the rules of the game
+func (g *Game) AuditShots() error {
+	return g.auditor.Check(g.state.CopyOpponentShipsLeft())
+}
+
+// OpponentShipsLeft returns the numbers of the opponent's ships still afloat by length
+func (g *Game) OpponentShipsLeft() map[int]int {
+	return g.state.CopyOpponentShipsLeft()
+}
+
 // UpdatePlayerInfo updates player information
 func (g *Game) UpdatePlayerInfo(name string, description string) {
 	g.state.ModifyPlayerInformation(name, description)
@@ -157,7 +169,7 @@ func (g *Game) GetTopPlayerStats() (TopPlayerStats, error) {
 
 // MarkPlayerShip marks the player's ship on the board
 func (g *Game) MarkPlayerShip(coords string) {
-	x, y := mapToState(coords)
+	x, y, _ := appState.ParseCoord(coords)
 	g.state.AddShip(x, y)
 }
 
@@ -169,7 +181,7 @@ func (g *Game) GetPlayerCoords() []string {
 	for i, row := range states {
 		for j, s := range row {
 			if s == appState.Ship {
-				coords = append(coords, mapFromState(i, j))
+				coords = append(coords, appState.FormatCoord(i, j))
 			}
 		}
 	}
@@ -195,6 +207,24 @@ func (g *Game) GetPlayerStats(name string) GameStats {
 	return stats
 }
 
+// ErrNoServerStats is returned for games played without the game server, it keeps no statistics of their players
+var ErrNoServerStats = errors.New("statistics are kept only for games on the game server")
+
+// OpponentStats returns the statistics the game server keeps for the opponent of the current game
+func (g *Game) OpponentStats(nick string) (GameStat, error) {
+	if g.server != Server(g.Client) {
+		return GameStat{}, ErrNoServerStats
+	}
+	stats, err := g.Client.GetPlayerStats(nick)
+	if err != nil {
+		return GameStat{}, fmt.Errorf("error while fetching player's statistics: %w", err)
+	}
+	if len(stats) == 0 {
+		return GameStat{}, fmt.Errorf("no statistics of %s", nick)
+	}
+	return stats[0], nil
+}
+
 // GetPlayerLobby returns the list of players in the lobby
 func (c *Client) GetPlayerLobby() ([]LobbyPlayer, error) {
 	req, err := c.getRequest("/lobby")
@@ -217,6 +247,7 @@ func (c *Client) GetPlayerLobby() ([]LobbyPlayer, error) {
 // ClearState clears the game state
 func (g *Game) ClearState() {
 	g.state.ClearState()
+	g.auditor.Reset()
 }
 
 // UpdateLastGameStatus updates the status of the last game
@@ -231,17 +262,12 @@ func (g *Game) LastGameStatus() string {
 
 // AbortGame aborts the game
 func (g *Game) AbortGame() {
-	err := g.Client.AbortGame()
+	err := g.server.AbortGame()
 	if err != nil {
 		return
 	}
 }
 
-// mapFromState converts int to string
-func mapFromState(x, y int) string {
-	return string(byte(x+65)) + strconv.Itoa(y+1)
-}
-
 // isValidCoord checks if the coordinates are valid
 func isValidCoord(coord string) bool {
 	if len(coord) < 2 || len(coord) > 3 {
@@ -2,6 +2,7 @@ package httpClient
 
 import (
 	"battleships/internal/appState"
+	"battleships/internal/auditor"
 	"fmt"
 	"net/http"
 	"sort"
@@ -149,8 +150,20 @@ func (g GameStats) String() string {
 
 // Game represents a game
 type Game struct {
-	Client *Client
-	state  *appState.GameState
+	Client  *Client
+	server  Server
+	state   *appState.GameState
+	auditor *auditor.Auditor // Checks the results of the player's shots
+}
+
+// Server represents the game rules the client plays against, the remote API or an in-process game
+type Server interface {
+	StartGame(nick, desc, targetNick string, coords []string, botGame bool) (string, error)
+	GetGameStatus() (GameStatus, error)
+	GetGameBoard() (*GameBoard, error)
+	Fire(data FireData) (FireResult, error)
+	GetGameDescription() (GameDescription, error)
+	AbortGame() error
 }
 
 // GameState represents the game state
@@ -162,6 +175,7 @@ type GameState struct {
 	PlayerDesc   string         `json:"player_desc"`
 	OppDesc      string         `json:"opp_desc"`
 	OppShipsSunk map[int]int
+	PlayerFleet  []appState.ShipStatus
 }
 
 // Structures from the api_client.go file
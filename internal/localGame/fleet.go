@@ -0,0 +1,212 @@
+package localGame
+
+import (
+	"battleships/internal/appState"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// ErrInvalidFleet is returned when the layout does not match the rules
+var ErrInvalidFleet = errors.New("invalid fleet layout")
+
+// Fleet represents the ships of one player and the shots they received
+type Fleet struct {
+	ships [][][2]int   // Cells of every ship
+	owner [10][10]int  // Index of the ship covering each cell plus one, 0 for water
+	hits  [10][10]bool // Cells already shot at
+	left  map[int]int  // Number of cells not hit yet for every ship
+	coord []string     // Layout as coordinates
+}
+
+// NewFleet creates a fleet from ship coordinates and checks if it follows the rules:
+// ships of lengths 4, 3, 3, 2, 2, 2, 1, 1, 1, 1, straight and not touching each other, even diagonally
+func NewFleet(coords []string) (*Fleet, error) {
+	var cells [10][10]bool
+	for _, c := range coords {
+		x, y, err := appState.ParseCoord(c)
+		if err != nil {
+			return nil, err
+		}
+		if cells[x][y] {
+			return nil, fmt.Errorf("%w: %s is used twice", ErrInvalidFleet, c)
+		}
+		cells[x][y] = true
+	}
+
+	f := &Fleet{left: make(map[int]int)}
+	var visited [10][10]bool
+	var lengths []int
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if !cells[x][y] || visited[x][y] {
+				continue
+			}
+			// Cells touching in any direction belong to one ship, so touching ships are not straight
+			ship := collectTouching(x, y, &cells, &visited)
+			if !isStraight(ship) {
+				return nil, fmt.Errorf("%w: ship at %s is bent or touches another ship", ErrInvalidFleet, appState.FormatCoord(x, y))
+			}
+			f.ships = append(f.ships, ship)
+			for _, c := range ship {
+				f.owner[c[0]][c[1]] = len(f.ships)
+			}
+			f.left[len(f.ships)-1] = len(ship)
+			lengths = append(lengths, len(ship))
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(lengths)))
+	if fmt.Sprint(lengths) != fmt.Sprint(appState.FleetLengths) {
+		return nil, fmt.Errorf("%w: ship lengths %v, expected %v", ErrInvalidFleet, lengths, appState.FleetLengths)
+	}
+	f.coord = append([]string{}, coords...)
+	return f, nil
+}
+
+// collectTouching finds all ship cells touching the given one, including diagonal neighbours
+func collectTouching(x, y int, cells *[10][10]bool, visited *[10][10]bool) [][2]int {
+	visited[x][y] = true
+	ship := [][2]int{{x, y}}
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			xA, yA := x+dx, y+dy
+			if xA >= 0 && xA < 10 && yA >= 0 && yA < 10 && cells[xA][yA] && !visited[xA][yA] {
+				ship = append(ship, collectTouching(xA, yA, cells, visited)...)
+			}
+		}
+	}
+	return ship
+}
+
+// isStraight checks if the cells form one horizontal or vertical line without gaps
+func isStraight(ship [][2]int) bool {
+	minX, maxX, minY, maxY := 10, -1, 10, -1
+	for _, c := range ship {
+		minX, maxX = min(minX, c[0]), max(maxX, c[0])
+		minY, maxY = min(minY, c[1]), max(maxY, c[1])
+	}
+	if minX != maxX && minY != maxY {
+		return false
+	}
+	return (maxX-minX)+(maxY-minY)+1 == len(ship)
+}
+
+// Coords returns the layout of the fleet as coordinates
+func (f *Fleet) Coords() []string {
+	return append([]string{}, f.coord...)
+}
+
+// Shoot marks a shot at the fleet and returns its result
+func (f *Fleet) Shoot(x, y int) string {
+	ship := f.owner[x][y] - 1
+	if ship < 0 {
+		f.hits[x][y] = true
+		return appState.ResultMiss
+	}
+	if !f.hits[x][y] {
+		f.hits[x][y] = true
+		f.left[ship]--
+	}
+	if f.left[ship] == 0 {
+		return appState.ResultSunk
+	}
+	return appState.ResultHit
+}
+
+// IsShot checks if the cell was already shot at
+func (f *Fleet) IsShot(x, y int) bool {
+	return f.hits[x][y]
+}
+
+// IsShip checks if a ship covers the cell
+func (f *Fleet) IsShip(x, y int) bool {
+	return f.owner[x][y] > 0
+}
+
+// ShipAt returns the cells of the ship covering the cell, nil for water
+func (f *Fleet) ShipAt(x, y int) [][2]int {
+	if f.owner[x][y] == 0 {
+		return nil
+	}
+	return f.ships[f.owner[x][y]-1]
+}
+
+// AllSunk checks if every ship of the fleet was sunk
+func (f *Fleet) AllSunk() bool {
+	for _, l := range f.left {
+		if l > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RandomLayout returns coordinates of a random fleet following the rules
+func RandomLayout(rng *rand.Rand) []string {
+	for {
+		if coords, ok := tryRandomLayout(rng); ok {
+			return coords
+		}
+	}
+}
+
+// tryRandomLayout places ships one by one at random, it gives up when a ship does not fit
+func tryRandomLayout(rng *rand.Rand) ([]string, bool) {
+	var blocked [10][10]bool
+	var coords []string
+	for _, length := range appState.FleetLengths {
+		placed := false
+		for attempt := 0; attempt < 200 && !placed; attempt++ {
+			horizontal := rng.Intn(2) == 0
+			x, y := rng.Intn(10), rng.Intn(10)
+			ship, ok := shipCells(x, y, length, horizontal)
+			if !ok || isBlocked(ship, &blocked) {
+				continue
+			}
+			for _, c := range ship {
+				coords = append(coords, appState.FormatCoord(c[0], c[1]))
+				// Cells around the ship can not be used by other ships
+				for dx := -1; dx <= 1; dx++ {
+					for dy := -1; dy <= 1; dy++ {
+						if xA, yA := c[0]+dx, c[1]+dy; xA >= 0 && xA < 10 && yA >= 0 && yA < 10 {
+							blocked[xA][yA] = true
+						}
+					}
+				}
+			}
+			placed = true
+		}
+		if !placed {
+			return nil, false
+		}
+	}
+	return coords, true
+}
+
+// shipCells returns the cells of a ship starting at the given cell, false when it leaves the board
+func shipCells(x, y, length int, horizontal bool) ([][2]int, bool) {
+	var ship [][2]int
+	for i := 0; i < length; i++ {
+		c := [2]int{x, y + i}
+		if horizontal {
+			c = [2]int{x + i, y}
+		}
+		if c[0] > 9 || c[1] > 9 {
+			return nil, false
+		}
+		ship = append(ship, c)
+	}
+	return ship, true
+}
+
+// isBlocked checks if any cell of the ship is blocked
+func isBlocked(ship [][2]int, blocked *[10][10]bool) bool {
+	for _, c := range ship {
+		if blocked[c[0]][c[1]] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package localGame
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/httpClient"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DefaultTurnTime is the time for a shot, the same as on the server
+const DefaultTurnTime = 60 * time.Second
+
+// Errors returned by the in-process game
+var (
+	ErrNotStarted  = errors.New("game has not started yet")
+	ErrEnded       = errors.New("game has ended")
+	ErrNotYourTurn = errors.New("it is not your turn")
+	ErrAlreadyShot = errors.New("field was already shot at")
+)
+
+// Match represents a game between two players played by the in-process rules
+type Match struct {
+	seats    [2]*Seat      // Both players of the match
+	turn     int           // Index of the player who fires now
+	turnTime time.Duration // Time for a shot
+	deadline time.Time     // End of the current turn
+	started  bool          // Information if both players joined
+	ended    bool          // Information if the match ended
+	winner   int           // Index of the winner once the match ended
+	rng      *rand.Rand    // Source of random layouts
+	mu       sync.Mutex    // Mutex for data access synchronization
+}
+
+// Seat represents one player of a match, it implements httpClient.Server for that player
+type Seat struct {
+	match    *Match
+	index    int      // Index of the player in the match
+	nick     string   // Player's nickname
+	desc     string   // Player's description
+	fleet    *Fleet   // Player's ships
+	joined   bool     // Information if the player started the game
+	oppShots []string // Shots fired at this player
+}
+
+// NewMatch creates a new match with the given time for a shot
+func NewMatch(turnTime time.Duration, rng *rand.Rand) *Match {
+	m := &Match{turnTime: turnTime, rng: rng}
+	for i := range m.seats {
+		m.seats[i] = &Seat{match: m, index: i}
+	}
+	return m
+}
+
+// Seat returns the player with the given index, 0 fires first
+func (m *Match) Seat(i int) *Seat {
+	return m.seats[i]
+}
+
+// StartTurn restarts the timer of the current turn
+func (m *Match) StartTurn() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deadline = time.Now().Add(m.turnTime)
+}
+
+// Turn returns the index of the player who fires now
+func (m *Match) Turn() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.turn
+}
+
+// Result returns the index of the winner, false while the match is in progress
+func (m *Match) Result() (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkTimeout()
+	return m.winner, m.ended
+}
+
+// checkTimeout ends the match when the current player ran out of time, the caller has to hold the mutex
+func (m *Match) checkTimeout() {
+	if m.started && !m.ended && time.Now().After(m.deadline) {
+		m.end(1 - m.turn)
+	}
+}
+
+// end ends the match, the caller has to hold the mutex
+func (m *Match) end(winner int) {
+	m.ended = true
+	m.winner = winner
+}
+
+// opponent returns the other player of the match
+func (s *Seat) opponent() *Seat {
+	return s.match.seats[1-s.index]
+}
+
+// StartGame joins the match with the given fleet, a random fleet is used when coords are empty
+func (s *Seat) StartGame(nick, desc, _ string, coords []string, _ bool) (string, error) {
+	m := s.match
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(coords) == 0 {
+		coords = RandomLayout(m.rng)
+	}
+	fleet, err := NewFleet(coords)
+	if err != nil {
+		return "", err
+	}
+	s.nick, s.desc, s.fleet, s.joined = nick, desc, fleet, true
+	if s.opponent().joined {
+		m.started = true
+		m.deadline = time.Now().Add(m.turnTime)
+	}
+	return "local", nil
+}
+
+// GetGameStatus returns the game status seen by the player
+func (s *Seat) GetGameStatus() (httpClient.GameStatus, error) {
+	m := s.match
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkTimeout()
+
+	status := httpClient.GameStatus{
+		Nick:     s.nick,
+		Opponent: s.opponent().nick,
+		OppShots: append([]string{}, s.oppShots...),
+	}
+	switch {
+	case !m.started:
+		status.GameStatus = "waiting"
+	case m.ended:
+		status.GameStatus = "ended"
+		status.LastGameStatus = "lose"
+		if m.winner == s.index {
+			status.LastGameStatus = "win"
+		}
+	default:
+		status.GameStatus = "game_in_progress"
+		status.ShouldFire = m.turn == s.index
+		status.Timer = int((time.Until(m.deadline) + time.Second - 1) / time.Second)
+	}
+	return status, nil
+}
+
+// GetGameBoard returns the player's ships
+func (s *Seat) GetGameBoard() (*httpClient.GameBoard, error) {
+	m := s.match
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s.fleet == nil {
+		return nil, ErrNotStarted
+	}
+	return &httpClient.GameBoard{Board: s.fleet.Coords()}, nil
+}
+
+// Fire fires a shot at the opponent's fleet
+func (s *Seat) Fire(data httpClient.FireData) (httpClient.FireResult, error) {
+	m := s.match
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkTimeout()
+
+	switch {
+	case !m.started:
+		return httpClient.FireResult{}, ErrNotStarted
+	case m.ended:
+		return httpClient.FireResult{}, ErrEnded
+	case m.turn != s.index:
+		return httpClient.FireResult{}, ErrNotYourTurn
+	}
+	x, y, err := appState.ParseCoord(data.Coord)
+	if err != nil {
+		return httpClient.FireResult{}, err
+	}
+
+	opp := s.opponent()
+	if opp.fleet.IsShot(x, y) {
+		return httpClient.FireResult{}, ErrAlreadyShot
+	}
+	result := opp.fleet.Shoot(x, y)
+	opp.oppShots = append(opp.oppShots, data.Coord)
+	if result == appState.ResultMiss {
+		m.turn = opp.index
+	}
+	m.deadline = time.Now().Add(m.turnTime)
+	if opp.fleet.AllSunk() {
+		m.end(s.index)
+	}
+	return httpClient.FireResult{Result: result}, nil
+}
+
+// GetGameDescription returns the nicknames and descriptions of both players
+func (s *Seat) GetGameDescription() (httpClient.GameDescription, error) {
+	m := s.match
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	opp := s.opponent()
+	return httpClient.GameDescription{Nick: s.nick, Desc: s.desc, Opponent: opp.nick, OppDesc: opp.desc}, nil
+}
+
+// AbortGame gives up the match
+func (s *Seat) AbortGame() error {
+	m := s.match
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.ended {
+		m.end(1 - s.index)
+	}
+	m.started = true
+	return nil
+}
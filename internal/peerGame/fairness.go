@@ -0,0 +1,119 @@
+package peerGame
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// saltSize is the number of random bytes mixed into a fleet commitment
+const saltSize = 16
+
+// shotRecord represents a shot of the player and the result reported by the opponent
+type shotRecord struct {
+	Coord  string
+	Result string
+}
+
+// FairnessReport represents the result of checking the opponent's answers against their revealed fleet
+type FairnessReport struct {
+	Verified bool     // Information if the opponent revealed a fleet matching their commitment
+	Cheating []string // Descriptions of every detected lie, empty for a fair game
+}
+
+// String returns the formatted report
+func (r FairnessReport) String() string {
+	if len(r.Cheating) > 0 {
+		return "Cheating detected:\n - " + strings.Join(r.Cheating, "\n - ")
+	}
+	if !r.Verified {
+		return "The opponent's answers could not be verified"
+	}
+	return "The opponent played fair, every answer matches the revealed fleet"
+}
+
+// newSalt returns a random salt for a commitment
+func newSalt() (string, error) {
+	b := make([]byte, saltSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// commitment returns the salted hash of a fleet layout, independent of the order of coordinates
+func commitment(salt string, layout []string) string {
+	sorted := append([]string{}, layout...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(salt + ":" + strings.Join(sorted, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyReveal checks the revealed fleet against the commitment and the reported results of the player's shots
+func verifyReveal(commit, salt string, layout []string, shots []shotRecord) FairnessReport {
+	if commit == "" {
+		return FairnessReport{Cheating: []string{"the opponent did not commit to a fleet before the game"}}
+	}
+	if commitment(salt, layout) != commit {
+		return FairnessReport{Cheating: []string{"the revealed fleet does not match the commitment sent before the first shot"}}
+	}
+	fleet, err := localGame.NewFleet(layout)
+	if err != nil {
+		return FairnessReport{Cheating: []string{fmt.Sprintf("the revealed fleet breaks the rules: %v", err)}}
+	}
+
+	report := FairnessReport{Verified: true}
+	for _, s := range shots {
+		x, y, err := appState.ParseCoord(s.Coord)
+		if err != nil {
+			continue
+		}
+		if expected := fleet.Shoot(x, y); expected != s.Result {
+			report.Cheating = append(report.Cheating,
+				fmt.Sprintf("shot at %s was reported as %s, but the revealed fleet says %s", s.Coord, s.Result, expected))
+		}
+	}
+	return report
+}
+
+// Verify checks every answer of the opponent against the fleet they revealed when the game ended.
+// It has to be called after the game ended and waits for the fleet until the connection is lost
+// or until the given time passed since the end of the game.
+func (p *Peer) Verify(timeout time.Duration) FairnessReport {
+	p.mu.Lock()
+	wait := time.Until(p.endedAt.Add(timeout))
+	p.mu.Unlock()
+
+	select {
+	case reveal := <-p.reveals:
+		return p.verify(reveal)
+	case <-p.gone:
+	case <-time.After(wait):
+	}
+	// The fleet could come just before the connection closed
+	select {
+	case reveal := <-p.reveals:
+		return p.verify(reveal)
+	default:
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.shots) == 0 {
+		return FairnessReport{}
+	}
+	return FairnessReport{Cheating: []string{"the opponent did not reveal their fleet after the game"}}
+}
+
+// verify checks the answers to the player's shots against the revealed fleet
+func (p *Peer) verify(reveal message) FairnessReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return verifyReveal(p.oppCommit, reveal.Salt, reveal.Layout, p.shots)
+}
@@ -0,0 +1,472 @@
+// Package peerGame lets two clients play directly over TCP, without the central server.
+//
+// One client hosts the game on a TCP port and the other joins it by address.
+// Both sides send newline-delimited JSON messages:
+//
+//	{"type":"hello","nick":"...","desc":"...","commit":"..."} sent by both sides right after connecting
+//	{"type":"fire","coord":"E7"}                             a shot at the receiver's fleet
+//	{"type":"result","coord":"E7","result":"hit"}            answer to a shot: miss, hit or sunk
+//	{"type":"timeout"}                                       the sender ran out of time and loses
+//	{"type":"abandon"}                                       the sender leaves the game and loses
+//	{"type":"reveal","salt":"...","layout":["A1","A2",...]}  the sender's fleet, sent once the game ended
+//
+// The host fires first. A hit or a sunk ship lets the shooter fire again, a miss passes the turn.
+// Each side answers shots from its own fleet and loses once all of its ships are sunk.
+//
+// The commit field of hello is the hex SHA-256 of the salt, a colon and the sorted fleet coordinates
+// joined with commas. After the game both sides reveal the salt and the fleet, so each of them can
+// check that every answer it received matches the fleet the opponent committed to before the first shot.
+package peerGame
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/httpClient"
+	"battleships/internal/localGame"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+// Message types of the protocol
+const (
+	msgHello   = "hello"
+	msgFire    = "fire"
+	msgResult  = "result"
+	msgTimeout = "timeout"
+	msgAbandon = "abandon"
+	msgReveal  = "reveal"
+)
+
+// timeoutGrace is the extra time given to the opponent before their turn is considered lost
+const timeoutGrace = 10 * time.Second
+
+// resultTimeout limits the time of waiting for the answer to a shot
+const resultTimeout = 15 * time.Second
+
+// Errors returned by the peer game
+var (
+	ErrNotConnected = errors.New("opponent is not connected")
+	ErrEnded        = errors.New("game has ended")
+	ErrNotYourTurn  = errors.New("it is not your turn")
+	ErrAlreadyShot  = errors.New("field was already shot at")
+	ErrWaiting      = errors.New("the previous shot has not been answered yet")
+)
+
+// message represents a single message of the protocol
+type message struct {
+	Type   string   `json:"type"`
+	Nick   string   `json:"nick,omitempty"`
+	Desc   string   `json:"desc,omitempty"`
+	Coord  string   `json:"coord,omitempty"`
+	Result string   `json:"result,omitempty"`
+	Commit string   `json:"commit,omitempty"`
+	Salt   string   `json:"salt,omitempty"`
+	Layout []string `json:"layout,omitempty"`
+}
+
+// Peer represents one side of a direct game, it implements httpClient.Server
+type Peer struct {
+	listener  net.Listener     // Listener of the host, nil when joining
+	addr      string           // Address to join, empty when hosting
+	conn      net.Conn         // Connection to the opponent
+	enc       *json.Encoder    // Encoder of outgoing messages
+	nick      string           // Player's nickname
+	desc      string           // Player's description
+	oppNick   string           // Opponent's nickname
+	oppDesc   string           // Opponent's description
+	fleet     *localGame.Fleet // Player's ships
+	myTurn    bool             // Information if the player fires now
+	deadline  time.Time        // End of the current turn
+	started   bool             // Information if both sides said hello
+	ended     bool             // Information if the game ended
+	endedAt   time.Time        // Time the game ended
+	won       bool             // Information if the player won
+	oppShots  []string         // Shots fired at the player
+	sunk      int              // Number of the opponent's ships sunk by the player
+	results   chan message     // Answers to the player's shots
+	waiting   string           // Player's shot waiting for its answer, empty when none
+	salt      string           // Salt of the player's fleet commitment
+	oppCommit string           // Opponent's fleet commitment
+	shots     []shotRecord     // Player's shots with the results reported by the opponent
+	reveals   chan message     // Opponent's fleet revealed after the game
+	gone      chan struct{}    // Closed when the connection to the opponent is lost
+	rng       *rand.Rand       // Source of random layouts
+	mu        sync.Mutex       // Mutex for data access synchronization
+	writeMu   sync.Mutex       // Mutex keeping outgoing messages whole
+}
+
+// Host creates a peer waiting for an opponent on the given address, like ":7777"
+func Host(addr string) (*Peer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error hosting the game: %w", err)
+	}
+	return newPeer(listener, ""), nil
+}
+
+// Join creates a peer connecting to a hosted game at the given address, like "192.168.1.5:7777"
+func Join(addr string) *Peer {
+	return newPeer(nil, addr)
+}
+
+// newPeer creates a peer
+func newPeer(listener net.Listener, addr string) *Peer {
+	return &Peer{
+		listener: listener,
+		addr:     addr,
+		myTurn:   listener != nil,
+		results:  make(chan message, 1),
+		reveals:  make(chan message, 1),
+		gone:     make(chan struct{}),
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Addr returns the address the host listens on
+func (p *Peer) Addr() string {
+	if p.listener == nil {
+		return p.addr
+	}
+	return p.listener.Addr().String()
+}
+
+// StartGame sets the player's fleet and connects to the opponent in the background
+func (p *Peer) StartGame(nick, desc, _ string, coords []string, _ bool) (string, error) {
+	if len(coords) == 0 {
+		coords = localGame.RandomLayout(p.rng)
+	}
+	fleet, err := localGame.NewFleet(coords)
+	if err != nil {
+		return "", err
+	}
+	salt, err := newSalt()
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	p.nick, p.desc, p.fleet, p.salt = nick, desc, fleet, salt
+	p.mu.Unlock()
+
+	go p.connect()
+	return "peer", nil
+}
+
+// connect waits for the opponent or joins them, then reads their messages
+func (p *Peer) connect() {
+	var conn net.Conn
+	var err error
+	if p.listener != nil {
+		conn, err = p.listener.Accept()
+		_ = p.listener.Close()
+	} else {
+		conn, err = net.DialTimeout("tcp", p.addr, resultTimeout)
+	}
+	if err != nil {
+		p.mu.Lock()
+		p.ended = true
+		p.mu.Unlock()
+		return
+	}
+
+	p.mu.Lock()
+	p.conn = conn
+	p.enc = json.NewEncoder(conn)
+	hello := message{Type: msgHello, Nick: p.nick, Desc: p.desc, Commit: commitment(p.salt, p.fleet.Coords())}
+	p.mu.Unlock()
+
+	if err := p.send(hello); err != nil {
+		p.disconnected()
+		return
+	}
+	p.read(conn)
+}
+
+// read handles the opponent's messages until the connection closes
+func (p *Peer) read(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var m message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			continue
+		}
+		p.handle(m)
+	}
+	p.disconnected()
+}
+
+// handle handles a single message of the opponent
+func (p *Peer) handle(m message) {
+	switch m.Type {
+	case msgHello:
+		p.mu.Lock()
+		p.oppNick, p.oppDesc, p.oppCommit = m.Nick, m.Desc, m.Commit
+		p.started = true
+		p.deadline = time.Now().Add(localGame.DefaultTurnTime)
+		p.mu.Unlock()
+	case msgFire:
+		p.answer(m.Coord)
+	case msgResult:
+		if !p.fired(m.Coord, m.Result) {
+			return
+		}
+		select {
+		case p.results <- m:
+		default:
+			// Nobody waits for this answer, it came after the shot timed out
+		}
+	case msgTimeout, msgAbandon:
+		p.mu.Lock()
+		p.finish(true)
+		p.mu.Unlock()
+	case msgReveal:
+		select {
+		case p.reveals <- m:
+		default:
+			// Only the first reveal counts
+		}
+	}
+}
+
+// answer answers the opponent's shot from the player's fleet
+func (p *Peer) answer(coord string) {
+	p.mu.Lock()
+	x, y, err := appState.ParseCoord(coord)
+	if err != nil || !p.started || p.ended || p.myTurn || p.fleet.IsShot(x, y) {
+		p.mu.Unlock()
+		return
+	}
+	result := p.fleet.Shoot(x, y)
+	p.oppShots = append(p.oppShots, coord)
+	if result == appState.ResultMiss {
+		p.myTurn = true
+	}
+	p.deadline = time.Now().Add(localGame.DefaultTurnTime)
+	lost := p.fleet.AllSunk()
+	p.mu.Unlock()
+
+	// The last answer goes out before the fleet is revealed
+	_ = p.send(message{Type: msgResult, Coord: coord, Result: result})
+	if lost {
+		p.mu.Lock()
+		p.finish(false)
+		p.mu.Unlock()
+	}
+}
+
+// send sends a message to the opponent
+func (p *Peer) send(m message) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	p.mu.Lock()
+	enc := p.enc
+	p.mu.Unlock()
+	if enc == nil {
+		return ErrNotConnected
+	}
+	return enc.Encode(m)
+}
+
+// disconnected ends the game when the connection is lost, a missing opponent loses
+func (p *Peer) disconnected() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.ended {
+		p.finish(p.started)
+	}
+	close(p.gone)
+}
+
+// finish ends the game and reveals the player's fleet to the opponent, the caller has to hold the mutex
+func (p *Peer) finish(won bool) {
+	if p.ended {
+		return
+	}
+	p.ended = true
+	p.endedAt = time.Now()
+	p.won = won
+	if p.started {
+		go p.reveal(message{Type: msgReveal, Salt: p.salt, Layout: p.fleet.Coords()})
+	}
+}
+
+// reveal sends the player's fleet and the salt of its commitment to the opponent
+func (p *Peer) reveal(m message) {
+	_ = p.send(m)
+}
+
+// checkTimeout ends the game when the current player ran out of time, the caller has to hold the mutex.
+// It returns true when the player ran out of time and the opponent has to be told.
+func (p *Peer) checkTimeout() bool {
+	if !p.started || p.ended {
+		return false
+	}
+	switch {
+	case p.myTurn && time.Now().After(p.deadline):
+		p.finish(false)
+		return true
+	case !p.myTurn && time.Now().After(p.deadline.Add(timeoutGrace)):
+		p.finish(true)
+	}
+	return false
+}
+
+// GetGameStatus returns the game status seen by the player
+func (p *Peer) GetGameStatus() (httpClient.GameStatus, error) {
+	p.mu.Lock()
+	lost := p.checkTimeout()
+	status := httpClient.GameStatus{
+		Nick:     p.nick,
+		Opponent: p.oppNick,
+		OppShots: append([]string{}, p.oppShots...),
+	}
+	switch {
+	case p.ended:
+		status.GameStatus = "ended"
+		status.LastGameStatus = "lose"
+		if p.won {
+			status.LastGameStatus = "win"
+		}
+	case !p.started:
+		status.GameStatus = "waiting"
+	default:
+		status.GameStatus = "game_in_progress"
+		status.ShouldFire = p.myTurn
+		status.Timer = max(int((time.Until(p.deadline)+time.Second-1)/time.Second), 0)
+	}
+	p.mu.Unlock()
+
+	if lost {
+		_ = p.send(message{Type: msgTimeout})
+	}
+	return status, nil
+}
+
+// GetGameBoard returns the player's ships
+func (p *Peer) GetGameBoard() (*httpClient.GameBoard, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fleet == nil {
+		return nil, ErrNotConnected
+	}
+	return &httpClient.GameBoard{Board: p.fleet.Coords()}, nil
+}
+
+// Fire sends a shot to the opponent and waits for its result
+func (p *Peer) Fire(data httpClient.FireData) (httpClient.FireResult, error) {
+	p.mu.Lock()
+	switch {
+	case p.ended:
+		p.mu.Unlock()
+		return httpClient.FireResult{}, ErrEnded
+	case !p.started:
+		p.mu.Unlock()
+		return httpClient.FireResult{}, ErrNotConnected
+	case !p.myTurn:
+		p.mu.Unlock()
+		return httpClient.FireResult{}, ErrNotYourTurn
+	case p.waiting != "":
+		// The late answer still changes the turn, so no other shot can go out before it
+		p.mu.Unlock()
+		return httpClient.FireResult{}, ErrWaiting
+	case p.shotAt(data.Coord):
+		p.mu.Unlock()
+		return httpClient.FireResult{}, ErrAlreadyShot
+	}
+	p.waiting = data.Coord
+	p.mu.Unlock()
+
+	if err := p.send(message{Type: msgFire, Coord: data.Coord}); err != nil {
+		p.mu.Lock()
+		p.waiting = ""
+		p.mu.Unlock()
+		return httpClient.FireResult{}, err
+	}
+
+	timeout := time.After(resultTimeout)
+	for {
+		select {
+		case m := <-p.results:
+			// Answers to earlier shots were already applied when they came
+			if m.Coord == data.Coord {
+				return httpClient.FireResult{Result: m.Result}, nil
+			}
+		case <-timeout:
+			return httpClient.FireResult{}, fmt.Errorf("no answer from the opponent to the shot at %s yet", data.Coord)
+		}
+	}
+}
+
+// shotAt checks if the player already fired at the field, the caller has to hold the mutex
+func (p *Peer) shotAt(coord string) bool {
+	for _, s := range p.shots {
+		if s.Coord == coord {
+			return true
+		}
+	}
+	return false
+}
+
+// fired applies the opponent's answer to the player's shot, even one that came after the shot timed out.
+// It returns false when the answer is not for the shot waiting for it.
+func (p *Peer) fired(coord, result string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if coord == "" || coord != p.waiting {
+		return false
+	}
+	p.waiting = ""
+	p.shots = append(p.shots, shotRecord{Coord: coord, Result: result})
+	if result == appState.ResultMiss {
+		p.myTurn = false
+	}
+	if result == appState.ResultSunk {
+		p.sunk++
+		if p.sunk == len(appState.FleetLengths) {
+			p.finish(true)
+		}
+	}
+	p.deadline = time.Now().Add(localGame.DefaultTurnTime)
+	return true
+}
+
+// GetGameDescription returns the nicknames and descriptions of both players
+func (p *Peer) GetGameDescription() (httpClient.GameDescription, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return httpClient.GameDescription{Nick: p.nick, Desc: p.desc, Opponent: p.oppNick, OppDesc: p.oppDesc}, nil
+}
+
+// AbortGame leaves the game, the opponent wins
+func (p *Peer) AbortGame() error {
+	p.mu.Lock()
+	wasEnded := p.ended
+	p.finish(false)
+	p.mu.Unlock()
+
+	if !wasEnded {
+		return p.send(message{Type: msgAbandon})
+	}
+	return nil
+}
+
+// Close closes the connection to the opponent
+func (p *Peer) Close() error {
+	p.mu.Lock()
+	p.finish(false)
+	conn, listener := p.conn, p.listener
+	p.mu.Unlock()
+
+	if listener != nil {
+		_ = listener.Close()
+	}
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package strategy
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"math/rand"
+)
+
+// Names of the built-in strategies
+const (
+	Random     = "random"
+	HuntTarget = "hunt-target"
+	Parity     = "parity"
+)
+
+func init() {
+	Register(Random, func(rng *rand.Rand) Strategy { return &tieredStrategy{name: Random, rng: rng} })
+	Register(HuntTarget, func(rng *rand.Rand) Strategy { return &tieredStrategy{name: HuntTarget, rng: rng, target: true} })
+	Register(Parity, func(rng *rand.Rand) Strategy {
+		return &tieredStrategy{name: Parity, rng: rng, target: true, parity: true}
+	})
+}
+
+// tieredStrategy fires at a random cell of the first non-empty group of candidates
+type tieredStrategy struct {
+	name   string     // Registered name
+	rng    *rand.Rand // Source of random choices
+	target bool       // Information if cells next to unfinished hits go first
+	parity bool       // Information if hunting uses a checkerboard pattern
+}
+
+// Name returns the name the strategy is registered with
+func (s *tieredStrategy) Name() string {
+	return s.name
+}
+
+// Place returns a random valid fleet
+func (s *tieredStrategy) Place() []string {
+	return localGame.RandomLayout(s.rng)
+}
+
+// NextShot prefers lines of hits, then cells next to hits, then a checkerboard pattern, then any free cell
+func (s *tieredStrategy) NextShot(board [10][10]string, _ map[int]int) (int, int, bool) {
+	var lineTargets, targets, parity, free [][2]int
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if board[x][y] != appState.Empty {
+				continue
+			}
+			free = append(free, [2]int{x, y})
+			if s.parity && (x+y)%2 == 0 {
+				parity = append(parity, [2]int{x, y})
+			}
+			if s.target && extendsHitLine(x, y, board) {
+				lineTargets = append(lineTargets, [2]int{x, y})
+			}
+			if s.target && isNextToOpenHit(x, y, board) {
+				targets = append(targets, [2]int{x, y})
+			}
+		}
+	}
+
+	for _, candidates := range [][][2]int{lineTargets, targets, parity, free} {
+		if len(candidates) > 0 {
+			c := candidates[s.rng.Intn(len(candidates))]
+			return c[0], c[1], true
+		}
+	}
+	return 0, 0, false
+}
+
+// Observe does nothing, the board already shows every result
+func (s *tieredStrategy) Observe(int, int, string) {}
+
+// isNextToOpenHit checks if the cell touches a hit that does not belong to a sunk ship yet
+func isNextToOpenHit(x, y int, board [10][10]string) bool {
+	for _, v := range appState.ShipDirections {
+		xA, yA := x+v[0], y+v[1]
+		if appState.IsWithinBoardLimits(xA, yA) && board[xA][yA] == appState.Hit {
+			return true
+		}
+	}
+	return false
+}
+
+// extendsHitLine checks if the cell continues a line of at least two unfinished hits
+func extendsHitLine(x, y int, board [10][10]string) bool {
+	for _, v := range appState.ShipDirections {
+		x1, y1 := x+v[0], y+v[1]
+		x2, y2 := x+2*v[0], y+2*v[1]
+		if appState.IsWithinBoardLimits(x2, y2) && board[x1][y1] == appState.Hit && board[x2][y2] == appState.Hit {
+			return true
+		}
+	}
+	return false
+}
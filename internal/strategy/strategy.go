@@ -0,0 +1,66 @@
+// Package strategy holds the strategies that place a fleet and choose shots, selected by name.
+//
+// A strategy sees the opponent's board the way appState keeps it, so the same strategy can fire
+// for the turn-timer safeguard, in autoplay games and in offline tournaments.
+// New strategies register themselves with Register, usually from an init function of their package.
+package strategy
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// Strategy chooses the placement of a fleet and the shots of a single game
+type Strategy interface {
+	// Name returns the name the strategy is registered with
+	Name() string
+	// Place returns the fields of all ships of the fleet
+	Place() []string
+	// NextShot chooses a field that was not shot at yet, false when there is none
+	NextShot(board [10][10]string, shipsLeft map[int]int) (int, int, bool)
+	// Observe receives the result of a shot: miss, hit or sunk
+	Observe(x, y int, result string)
+}
+
+// Factory creates a strategy for a new game, all random choices come from the given source
+type Factory func(rng *rand.Rand) Strategy
+
+var (
+	registry   = map[string]Factory{} // Registered strategies by name
+	registryMu sync.Mutex             // Mutex for registry access synchronization
+)
+
+// Register makes the strategy available by name, it panics when the name is already taken
+func Register(name string, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("strategy %q registered twice", name))
+	}
+	registry[name] = f
+}
+
+// New creates the strategy registered with the name
+func New(name string, rng *rand.Rand) (Strategy, error) {
+	registryMu.Lock()
+	f, ok := registry[name]
+	registryMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q, available strategies: %v", name, Names())
+	}
+	return f(rng), nil
+}
+
+// Names returns the names of all registered strategies in alphabetical order
+func Names() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,178 @@
+package tournament
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// LayoutConfig represents the settings of a layout analysis
+type LayoutConfig struct {
+	Layout     []string // Fields of the analysed fleet
+	Strategies []string // Names of the strategies shooting at the fleet
+	Games      int      // Number of games for every strategy
+	Seed       int64    // Seed every game seed is derived from
+}
+
+// LayoutReport represents how long strategies need to sink a fleet
+type LayoutReport struct {
+	Results []LayoutResult // Results of every strategy
+}
+
+// LayoutResult represents the results of a single strategy against the fleet and against random fleets
+type LayoutResult struct {
+	Strategy    string       // Name of the strategy
+	Games       int          // Number of games played against each kind of fleet
+	Shots       int          // Number of shots needed to sink the fleet in all games
+	RandomShots int          // Number of shots needed to sink random fleets in all games
+	Ships       []ShipResult // Ships of the fleet, the most exposed first
+}
+
+// ShipResult represents how soon a ship of the fleet goes down
+type ShipResult struct {
+	Fields    []string // Fields of the ship
+	SunkShots int      // Number of shots fired before the ship was sunk, summed over all games
+}
+
+// AnalyzeLayout fires at the fleet with every strategy and compares it with random fleets
+func AnalyzeLayout(cfg LayoutConfig) (LayoutReport, error) {
+	fleet, err := localGame.NewFleet(cfg.Layout)
+	if err != nil {
+		return LayoutReport{}, err
+	}
+	if cfg.Games < 1 || len(cfg.Strategies) == 0 {
+		return LayoutReport{}, fmt.Errorf("an analysis needs at least one game and one strategy")
+	}
+	ships := fleetShips(fleet, cfg.Layout)
+
+	var report LayoutReport
+	for s, name := range cfg.Strategies {
+		result := LayoutResult{Strategy: name, Games: cfg.Games}
+		sunkShots := make([]int, len(ships))
+		for g := 0; g < cfg.Games; g++ {
+			rng := rand.New(rand.NewSource(cfg.Seed + int64(s)*int64(cfg.Games) + int64(g)))
+
+			target, _ := localGame.NewFleet(cfg.Layout)
+			shots, sunk, err := sinkFleet(name, target, rand.New(rand.NewSource(rng.Int63())))
+			if err != nil {
+				return LayoutReport{}, err
+			}
+			result.Shots += shots
+			for i, ship := range ships {
+				sunkShots[i] += sunk[ship[0]]
+			}
+
+			random, _ := localGame.NewFleet(localGame.RandomLayout(rng))
+			shots, _, err = sinkFleet(name, random, rand.New(rand.NewSource(rng.Int63())))
+			if err != nil {
+				return LayoutReport{}, err
+			}
+			result.RandomShots += shots
+		}
+		for i, ship := range ships {
+			var fields []string
+			for _, c := range ship {
+				fields = append(fields, appState.FormatCoord(c[0], c[1]))
+			}
+			result.Ships = append(result.Ships, ShipResult{Fields: fields, SunkShots: sunkShots[i]})
+		}
+		sort.SliceStable(result.Ships, func(i, j int) bool { return result.Ships[i].SunkShots < result.Ships[j].SunkShots })
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+// fleetShips returns the cells of every ship of the fleet in the order of the layout
+func fleetShips(fleet *localGame.Fleet, layout []string) [][][2]int {
+	var ships [][][2]int
+	seen := make(map[[2]int]bool)
+	for _, c := range layout {
+		x, y, _ := appState.ParseCoord(c)
+		if ship := fleet.ShipAt(x, y); !seen[ship[0]] {
+			seen[ship[0]] = true
+			ships = append(ships, ship)
+		}
+	}
+	return ships
+}
+
+// sinkFleet fires with the strategy until the fleet is sunk, it returns the number of shots
+// and the number of shots after which every ship went down, keyed by the first cell of the ship
+func sinkFleet(name string, fleet *localGame.Fleet, rng *rand.Rand) (int, map[[2]int]int, error) {
+	shooter, err := newPlayer(name, rng)
+	if err != nil {
+		return 0, nil, err
+	}
+	target := &player{fleet: fleet, board: appState.InitializeNewGameState()}
+	sunk := make(map[[2]int]int)
+	for target.fleetLeft() {
+		x, y, result, ok := shooter.fire(target)
+		if !ok {
+			return 0, nil, fmt.Errorf("strategy %s could not sink the fleet", name)
+		}
+		if result == appState.ResultSunk {
+			sunk[fleet.ShipAt(x, y)[0]] = shooter.shots
+		}
+	}
+	return shooter.shots, sunk, nil
+}
+
+// AverageShots returns the average number of shots needed to sink the fleet by all strategies
+func (r LayoutReport) AverageShots() (float64, float64) {
+	shots, random, games := 0, 0, 0
+	for _, res := range r.Results {
+		shots += res.Shots
+		random += res.RandomShots
+		games += res.Games
+	}
+	return ratio(shots, games), ratio(random, games)
+}
+
+// MostExposed returns the ship sunk the soonest on average over all strategies
+func (r LayoutReport) MostExposed() ShipResult {
+	totals := make(map[string]int)
+	var exposed ShipResult
+	if len(r.Results) == 0 {
+		return exposed
+	}
+	for _, res := range r.Results {
+		for _, ship := range res.Ships {
+			totals[strings.Join(ship.Fields, " ")] += ship.SunkShots
+		}
+	}
+	best := -1
+	for _, ship := range r.Results[0].Ships {
+		if t := totals[strings.Join(ship.Fields, " ")]; best < 0 || t < best {
+			best, exposed = t, ship
+		}
+	}
+	return exposed
+}
+
+// Summary describes the analysis in a single line
+func (r LayoutReport) Summary() string {
+	shots, random := r.AverageShots()
+	exposed := r.MostExposed()
+	return fmt.Sprintf("%.1f shots to sink on average (random layouts: %.1f, %+.1f), most exposed: ship at %s",
+		shots, random, shots-random, strings.Join(exposed.Fields, " "))
+}
+
+// WriteText writes the results of every strategy and the ships sorted from the most exposed
+func (r LayoutReport) WriteText(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString(r.Summary() + "\n")
+	for _, res := range r.Results {
+		b.WriteString(fmt.Sprintf("\nStrategy %s, %d games: %.1f shots to sink this layout, %.1f for random layouts\n",
+			res.Strategy, res.Games, ratio(res.Shots, res.Games), ratio(res.RandomShots, res.Games)))
+		b.WriteString("  Ships from the most exposed, with the average number of shots fired before they were sunk:\n")
+		for _, ship := range res.Ships {
+			b.WriteString(fmt.Sprintf("  %-16s %5.1f\n", strings.Join(ship.Fields, " "), ratio(ship.SunkShots, res.Games)))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
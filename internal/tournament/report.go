@@ -0,0 +1,108 @@
+package tournament
+
+import (
+	"battleships/internal/appState"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// wilsonZ is the quantile of the normal distribution for 95% confidence intervals
+const wilsonZ = 1.959964
+
+// WilsonInterval returns the 95% Wilson score interval of the win rate
+func WilsonInterval(wins, games int) (float64, float64) {
+	if games == 0 {
+		return 0, 1
+	}
+	n := float64(games)
+	p := float64(wins) / n
+	z2 := wilsonZ * wilsonZ
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	margin := wilsonZ * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / (1 + z2/n)
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// WriteText writes the results as tables with a shot heatmap of every strategy.
+// Win rates and their intervals count only the games that had a winner.
+func (r Result) WriteText(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("Matches\n")
+	b.WriteString(fmt.Sprintf("%-14s %-14s %7s %7s %9s %17s %12s\n", "first", "second", "games", "capped", "first win", "95% interval", "avg length"))
+	for _, m := range r.Matches {
+		low, high := WilsonInterval(m.FirstWins, m.Decided())
+		b.WriteString(fmt.Sprintf("%-14s %-14s %7d %7d %8.1f%% %7.1f%% - %5.1f%% %12.1f\n",
+			m.First, m.Second, m.Games, m.Capped, percent(m.FirstWins, m.Decided()), 100*low, 100*high, ratio(m.TotalShots, m.Games)))
+	}
+
+	b.WriteString("\nStandings\n")
+	b.WriteString(fmt.Sprintf("%-14s %7s %7s %7s %9s %17s %15s\n", "strategy", "games", "capped", "wins", "win rate", "95% interval", "shots to win"))
+	for _, s := range r.Standings {
+		low, high := WilsonInterval(s.Wins, s.Decided())
+		b.WriteString(fmt.Sprintf("%-14s %7d %7d %7d %8.1f%% %7.1f%% - %5.1f%% %15.1f\n",
+			s.Strategy, s.Games, s.Capped, s.Wins, percent(s.Wins, s.Decided()), 100*low, 100*high, ratio(s.WinShots, s.Wins)))
+	}
+
+	for _, s := range r.Standings {
+		b.WriteString(fmt.Sprintf("\nShots of %s, percent of games in which a field was fired at\n", s.Strategy))
+		b.WriteString("      A    B    C    D    E    F    G    H    I    J\n")
+		for y := 0; y < 10; y++ {
+			b.WriteString(fmt.Sprintf("%3d", y+1))
+			for x := 0; x < 10; x++ {
+				b.WriteString(fmt.Sprintf(" %4.0f", percent(s.ShotCounts[x][y], s.Games)))
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCSV writes the results as CSV records, the first column tells the kind of every record
+func (r Result) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{{"record", "strategy", "opponent", "games", "capped", "wins", "win_rate", "ci_low", "ci_high", "avg_shots", "field", "fired_percent"}}
+	for _, m := range r.Matches {
+		low, high := WilsonInterval(m.FirstWins, m.Decided())
+		records = append(records, []string{"match", m.First, m.Second, strconv.Itoa(m.Games), strconv.Itoa(m.Capped), strconv.Itoa(m.FirstWins),
+			formatFloat(ratio(m.FirstWins, m.Decided())), formatFloat(low), formatFloat(high), formatFloat(ratio(m.TotalShots, m.Games)), "", ""})
+	}
+	for _, s := range r.Standings {
+		low, high := WilsonInterval(s.Wins, s.Decided())
+		records = append(records, []string{"standing", s.Strategy, "", strconv.Itoa(s.Games), strconv.Itoa(s.Capped), strconv.Itoa(s.Wins),
+			formatFloat(ratio(s.Wins, s.Decided())), formatFloat(low), formatFloat(high), formatFloat(ratio(s.WinShots, s.Wins)), "", ""})
+	}
+	for _, s := range r.Standings {
+		for y := 0; y < 10; y++ {
+			for x := 0; x < 10; x++ {
+				records = append(records, []string{"heatmap", s.Strategy, "", strconv.Itoa(s.Games), "", "", "", "", "", "", appState.FormatCoord(x, y),
+					formatFloat(percent(s.ShotCounts[x][y], s.Games))})
+			}
+		}
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// ratio divides two counts, zero when there is nothing to divide by
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
+
+// percent returns a count as a percentage of the total
+func percent(a, b int) float64 {
+	return 100 * ratio(a, b)
+}
+
+// formatFloat formats a number for CSV
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
@@ -0,0 +1,208 @@
+// Package tournament plays simulated games between strategies in-process and collects statistics.
+//
+// Every game gets its own seed derived from the tournament seed, so the same settings always give the same results.
+// The fleets follow the rules of localGame and the boards are tracked by appState, like in a real game.
+package tournament
+
+import (
+	"battleships/internal/appState"
+	"battleships/internal/localGame"
+	"battleships/internal/strategy"
+	"fmt"
+	"math/rand"
+)
+
+// maxShots ends a game that takes longer than any legal game can, it protects against broken strategies.
+// A game stopped there has no winner and does not count in win rates.
+const maxShots = 100
+
+// Config represents the settings of a tournament
+type Config struct {
+	Strategies []string // Names of the competing strategies, every pair plays a match
+	Games      int      // Number of games in every match
+	Seed       int64    // Seed every game seed is derived from
+}
+
+// Result represents the statistics of a finished tournament
+type Result struct {
+	Matches   []MatchResult // Results of every pair of strategies
+	Standings []Standing    // Results of every strategy in all of its matches
+}
+
+// MatchResult represents the results of all games between two strategies
+type MatchResult struct {
+	First, Second string // Names of the strategies
+	Games         int    // Number of games played
+	FirstWins     int    // Number of games won by the first strategy
+	Capped        int    // Number of games stopped at the shot limit without a winner
+	TotalShots    int    // Number of shots fired by both strategies in all games
+}
+
+// Standing represents the results of a strategy in the whole tournament
+type Standing struct {
+	Strategy   string      // Name of the strategy
+	Games      int         // Number of games played
+	Wins       int         // Number of games won
+	Capped     int         // Number of games stopped at the shot limit without a winner
+	WinShots   int         // Number of shots fired in won games
+	ShotCounts [10][10]int // Number of games in which the strategy fired at every cell
+}
+
+// player represents a strategy playing a single game
+type player struct {
+	strategy strategy.Strategy   // Strategy choosing the shots
+	fleet    *localGame.Fleet    // Player's own fleet
+	board    *appState.GameState // Opponent's board seen by the player
+	shots    int                 // Number of shots fired
+	fired    [10][10]bool        // Cells fired at
+}
+
+// Run plays a match between every pair of strategies
+func Run(cfg Config) (Result, error) {
+	if len(cfg.Strategies) < 2 {
+		return Result{}, fmt.Errorf("a tournament needs at least two strategies")
+	}
+	if cfg.Games < 1 {
+		return Result{}, fmt.Errorf("a match needs at least one game")
+	}
+	standings := make(map[string]*Standing)
+	for _, name := range cfg.Strategies {
+		if _, ok := standings[name]; ok {
+			return Result{}, fmt.Errorf("strategy %q entered twice", name)
+		}
+		if _, err := strategy.New(name, rand.New(rand.NewSource(cfg.Seed))); err != nil {
+			return Result{}, err
+		}
+		standings[name] = &Standing{Strategy: name}
+	}
+
+	var result Result
+	pair := 0
+	for i, first := range cfg.Strategies {
+		for _, second := range cfg.Strategies[i+1:] {
+			match := MatchResult{First: first, Second: second, Games: cfg.Games}
+			for g := 0; g < cfg.Games; g++ {
+				// Strategies take turns in starting the games of a match
+				seed := cfg.Seed + int64(pair)*int64(cfg.Games) + int64(g)
+				a, b, capped, err := playGame(first, second, seed, g%2 == 1)
+				if err != nil {
+					return Result{}, err
+				}
+				switch {
+				case capped:
+					match.Capped++
+				case a.fleetLeft():
+					match.FirstWins++
+				}
+				match.TotalShots += a.shots + b.shots
+				standings[first].add(a, a.fleetLeft(), capped)
+				standings[second].add(b, b.fleetLeft(), capped)
+			}
+			result.Matches = append(result.Matches, match)
+			pair++
+		}
+	}
+	for _, name := range cfg.Strategies {
+		result.Standings = append(result.Standings, *standings[name])
+	}
+	return result, nil
+}
+
+// playGame plays a single game, the players are returned in the order of the names.
+// It reports whether the game was stopped at the shot limit with both fleets afloat.
+func playGame(first, second string, seed int64, secondStarts bool) (*player, *player, bool, error) {
+	rng := rand.New(rand.NewSource(seed))
+	a, err := newPlayer(first, rand.New(rand.NewSource(rng.Int63())))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	b, err := newPlayer(second, rand.New(rand.NewSource(rng.Int63())))
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	shooter, target := a, b
+	if secondStarts {
+		shooter, target = b, a
+	}
+	for shooter.shots < maxShots && target.shots < maxShots {
+		_, _, result, ok := shooter.fire(target)
+		if !ok {
+			// A strategy that can not fire loses the game
+			shooter.fleet = nil
+			break
+		}
+		if !target.fleetLeft() {
+			break
+		}
+		if result == appState.ResultMiss {
+			shooter, target = target, shooter
+		}
+	}
+	return a, b, a.fleetLeft() && b.fleetLeft(), nil
+}
+
+// newPlayer creates a strategy and its fleet
+func newPlayer(name string, rng *rand.Rand) (*player, error) {
+	s, err := strategy.New(name, rng)
+	if err != nil {
+		return nil, err
+	}
+	fleet, err := localGame.NewFleet(s.Place())
+	if err != nil {
+		return nil, fmt.Errorf("strategy %s placed an invalid fleet: %w", name, err)
+	}
+	return &player{strategy: s, fleet: fleet, board: appState.InitializeNewGameState()}, nil
+}
+
+// fire fires a single shot at the target and returns the field and the result, false when the strategy found no field to fire at
+func (p *player) fire(target *player) (int, int, string, bool) {
+	x, y, ok := p.strategy.NextShot(p.board.GetOpponentBoard(), p.board.CopyOpponentShipsLeft())
+	if !ok || p.fired[x][y] {
+		return x, y, "", false
+	}
+	result := target.fleet.Shoot(x, y)
+	p.shots++
+	p.fired[x][y] = true
+	p.strategy.Observe(x, y, result)
+
+	mark := map[string]string{appState.ResultMiss: appState.Miss, appState.ResultHit: appState.Hit, appState.ResultSunk: appState.Sunk}[result]
+	if _, err := p.board.MarkOpponentBoard(x, y, mark); err != nil {
+		return x, y, "", false
+	}
+	return x, y, result, true
+}
+
+// fleetLeft checks if the player still has a ship afloat
+func (p *player) fleetLeft() bool {
+	return p.fleet != nil && !p.fleet.AllSunk()
+}
+
+// Decided returns the number of games of the match that had a winner
+func (m MatchResult) Decided() int {
+	return m.Games - m.Capped
+}
+
+// Decided returns the number of games of the strategy that had a winner
+func (s Standing) Decided() int {
+	return s.Games - s.Capped
+}
+
+// add adds a finished game to the standing, a capped game counts as neither a win nor a loss
+func (s *Standing) add(p *player, won, capped bool) {
+	s.Games++
+	switch {
+	case capped:
+		s.Capped++
+	case won:
+		s.Wins++
+		s.WinShots += p.shots
+	}
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if p.fired[x][y] {
+				s.ShotCounts[x][y]++
+			}
+		}
+	}
+}
@@ -1,13 +1,29 @@
 package main
 
 import (
+	"battleships/internal/appState"
+	"battleships/internal/boardFormat"
 	"battleships/internal/game"
+	"battleships/internal/history"
 	"battleships/internal/httpClient"
+	"battleships/internal/strategy"
+	"battleships/internal/tournament"
 	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
 )
 
 // main is the entry point of the application
 func main() {
+	// Parse command line options
+	plain := flag.Bool("plain", false, "use the plain-text game screen instead of the graphical one")
+	bot := flag.String("bot", "", "command starting a bot program that plays instead of you")
+	flag.Parse()
+
 	// Create a new context for managing the lifecycle of goroutines
 	ctx := context.Background()
 
@@ -16,11 +32,235 @@ func main() {
 
 	// Initialize a new game application with the created channels
 	app := game.NewApp(gameStatusChannel, playerShotsChannel, gameStateChannel)
+	if *plain {
+		app.UsePlainText(os.Stdin, os.Stdout)
+	}
+	if *bot != "" {
+		if err := app.UseBot(*bot); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer app.CloseBot()
+	}
+
+	// Run the chosen command
+	switch flag.Arg(0) {
+	case "serve-ui":
+		serveUI(app, flag.Args()[1:])
+	case "autoplay":
+		autoplay(ctx, flag.Args()[1:])
+		return
+	case "tournament":
+		runTournament(flag.Args()[1:])
+		return
+	case "grid":
+		runGrid(flag.Args()[1:])
+		return
+	case "analyze":
+		analyzeLayout(flag.Args()[1:])
+		return
+	case "":
+	default:
+		fmt.Printf("Unknown command %q, available commands: serve-ui, autoplay, tournament, grid, analyze\n", flag.Arg(0))
+		os.Exit(2)
+	}
 
 	// Start the game menu
 	app.Menu(ctx)
 }
 
+// serveUI switches the game screen to a browser served by a local HTTP server
+func serveUI(app *game.App, args []string) {
+	fs := flag.NewFlagSet("serve-ui", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "address of the local web server")
+	_ = fs.Parse(args)
+
+	web, err := game.NewWebUI(*addr)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("Open %s in your browser to see the game screen\n", web.URL())
+	app.UseWebUI(web)
+}
+
+// autoplay plays unattended games against the server bot and prints the results
+func autoplay(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("autoplay", flag.ExitOnError)
+	games := fs.Int("games", 10, "number of games to play")
+	name := fs.String("strategy", strategy.HuntTarget, "strategy placing ships and choosing shots: "+strings.Join(strategy.Names(), ", "))
+	nick := fs.String("nick", "", "nickname used in the games, random when empty")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "seed of the random choices of the strategy")
+	_ = fs.Parse(args)
+
+	// ctrl+c abandons the current game and prints the results so far
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	cfg := game.AutoplayConfig{Games: *games, Strategy: *name, Nick: *nick, Seed: *seed}
+	if err := game.Autoplay(ctx, httpClient.NewGame().Client, cfg, os.Stdout); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// runTournament plays simulated games between strategies and prints the statistics
+func runTournament(args []string) {
+	fs := flag.NewFlagSet("tournament", flag.ExitOnError)
+	games := fs.Int("games", 1000, "number of games between every pair of strategies")
+	names := fs.String("strategies", strings.Join(strategy.Names(), ","), "comma-separated strategies playing round-robin")
+	seed := fs.Int64("seed", 1, "seed of the tournament, the same seed gives the same results")
+	format := fs.String("format", "text", "output format: text or csv")
+	_ = fs.Parse(args)
+
+	result, err := tournament.Run(tournament.Config{Strategies: strings.Split(*names, ","), Games: *games, Seed: *seed})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	switch *format {
+	case "text":
+		err = result.WriteText(os.Stdout)
+	case "csv":
+		err = result.WriteCSV(os.Stdout)
+	default:
+		err = fmt.Errorf("unknown format %q, use text or csv", *format)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// runGrid prints the text grid of a fleet or board code or of a board of the last game, or the code of a grid file
+func runGrid(args []string) {
+	fs := flag.NewFlagSet("grid", flag.ExitOnError)
+	file := fs.String("file", "", "grid file to print the code of, instead of a code to print the grid of")
+	last := fs.String("last", "", "print the player or the opponent board of the last finished game")
+	fs.Usage = func() {
+		fmt.Println("Usage: battleships grid CODE | battleships grid -file PATH | battleships grid -last player|opponent")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *last != "" {
+		b, err := lastGameBoard(*last)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Print(boardFormat.FormatGrid(b))
+		return
+	}
+	if *file == "" {
+		if fs.NArg() != 1 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		b, err := boardFormat.DecodeBoard(fs.Arg(0))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Print(boardFormat.FormatGrid(b))
+		return
+	}
+
+	text, err := os.ReadFile(*file)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	b, err := boardFormat.ParseGrid(string(text))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	// A grid with nothing but ships is shared as the shorter fleet code
+	code, err := boardFormat.EncodeBoard(b)
+	if coords, fleetErr := boardFormat.FleetFromGrid(b); fleetErr == nil && !strings.ContainsAny(boardFormat.FormatGrid(b), "xo*-") {
+		code, err = boardFormat.EncodeFleet(coords)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println(code)
+}
+
+// lastGameBoard returns the player or the opponent board of the last finished game from the history
+func lastGameBoard(side string) (*appState.Board, error) {
+	if side != "player" && side != "opponent" {
+		return nil, fmt.Errorf("unknown board %q, use player or opponent", side)
+	}
+	path, err := history.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	games, err := history.Open(path).Games()
+	if err != nil {
+		return nil, err
+	}
+	if len(games) == 0 {
+		return nil, fmt.Errorf("no finished games in %s", path)
+	}
+	player, opponent := games[len(games)-1].Boards()
+	if side == "opponent" {
+		return opponent, nil
+	}
+	return player, nil
+}
+
+// analyzeLayout simulates games against a saved fleet and prints how hard it is to sink
+func analyzeLayout(args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	file := fs.String("file", "", "grid file with the fleet, instead of a fleet code")
+	games := fs.Int("games", 500, "number of games of every strategy")
+	names := fs.String("strategies", strings.Join(strategy.Names(), ","), "comma-separated strategies shooting at the fleet")
+	seed := fs.Int64("seed", 1, "seed of the analysis, the same seed gives the same results")
+	fs.Usage = func() {
+		fmt.Println("Usage: battleships analyze [options] CODE | battleships analyze [options] -file PATH")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	var layout []string
+	var err error
+	switch {
+	case *file != "":
+		var text []byte
+		if text, err = os.ReadFile(*file); err == nil {
+			var b *appState.Board
+			if b, err = boardFormat.ParseGrid(string(text)); err == nil {
+				layout, err = boardFormat.FleetFromGrid(b)
+			}
+		}
+	case fs.NArg() == 1:
+		layout, err = boardFormat.DecodeFleet(fs.Arg(0))
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	report, err := tournament.AnalyzeLayout(tournament.LayoutConfig{
+		Layout:     layout,
+		Strategies: strings.Split(*names, ","),
+		Games:      *games,
+		Seed:       *seed,
+	})
+	if err == nil {
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
 // createChannels is a helper function that creates and returns channels for game status, player shots, and game state
 func createChannels() (chan httpClient.GameStatus, chan string, chan httpClient.GameState) {
 	// Create a channel for game status updates